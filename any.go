@@ -12,6 +12,12 @@ type anyConstraint[ValueT any] struct {
 	constraints []Constraint[ValueT]
 }
 
+var (
+	_ Constraint[string]          = anyConstraint[string]{}
+	_ CompositeConstraint[string] = anyConstraint[string]{}
+	_ MessageConstraint           = anyConstraint[string]{}
+)
+
 func (ac anyConstraint[ValueT]) ConstraintDescription() string {
 	if ac.constraints != nil {
 		descs := make([]string, 0, len(ac.constraints))
@@ -23,6 +29,33 @@ func (ac anyConstraint[ValueT]) ConstraintDescription() string {
 	return ""
 }
 
+func (ac anyConstraint[ValueT]) ConstraintKind() Kind {
+	return KindAny
+}
+
+func (ac anyConstraint[ValueT]) ConstraintMessage() Message {
+	return Message{
+		ID:   string(KindAny),
+		Args: map[string]any{"constraints": messageList(ac.constraints)},
+		Text: ac.ConstraintDescription(),
+	}
+}
+
+// ConstraintList returns a copy of the alternatives.
+func (ac anyConstraint[ValueT]) ConstraintList() []Constraint[ValueT] {
+	if ac.constraints != nil {
+		copyConstraints := make([]Constraint[ValueT], len(ac.constraints))
+		copy(copyConstraints, ac.constraints)
+		return copyConstraints
+	}
+	return nil
+}
+
+// Children conforms CompositeConstraint interface.
+func (ac anyConstraint[ValueT]) Children() []Constraint[ValueT] {
+	return ac.ConstraintList()
+}
+
 func (ac anyConstraint[ValueT]) IsValid(v ValueT) bool {
 	if ac.constraints != nil {
 		for _, c := range ac.constraints {
@@ -0,0 +1,37 @@
+package constraints
+
+// CodedConstraint is implemented by constraints which have
+// a machine-readable violation code.
+type CodedConstraint interface {
+	ConstraintBase
+
+	// ConstraintCode returns the violation code of the constraint,
+	// e.g., "length.min".
+	ConstraintCode() string
+}
+
+// CodeOf returns the machine-readable violation code of constraint c,
+// e.g., "min", "length.min" or "one_of". Clients could switch on the
+// code instead of comparing descriptions.
+//
+// If c doesn't implement CodedConstraint, the code is the ID of its
+// Message. It returns an empty string for constraints which have
+// neither, e.g., those created with Func without options.
+//
+// API status: experimental
+func CodeOf(c ConstraintBase) string {
+	if c == nil {
+		return ""
+	}
+	if cc, ok := c.(CodedConstraint); ok {
+		return cc.ConstraintCode()
+	}
+	return MessageOf(c).ID
+}
+
+// ParamsOf returns the parameters of constraint c, e.g., {"min": 6}
+// for StringMinLength(6). The parameters are the arguments of
+// the Message of c.
+func ParamsOf(c ConstraintBase) map[string]any {
+	return MessageOf(c).Args
+}
@@ -0,0 +1,28 @@
+package constraints
+
+import "testing"
+
+func TestCodeOf(t *testing.T) {
+	assertEq(t, "min", CodeOf(Min(5)))
+	assertEq(t, "max", CodeOf(Max(5)))
+	assertEq(t, "less_than", CodeOf(LessThan(5)))
+	assertEq(t, "range", CodeOf(Range(1, 5)))
+	assertEq(t, "one_of", CodeOf(OneOf(1, 2)))
+	assertEq(t, "match", CodeOf(Match(1)))
+	assertEq(t, "not", CodeOf(Negate[int](Match(1), "")))
+	assertEq(t, "", CodeOf(Negate[int](Match(1), "anything but one")))
+	assertEq(t, "not_one", CodeOf(Negate[int](Match(1), "anything but one",
+		WithCode("not_one"))))
+	assertEq(t, "", CodeOf(Func("any", func(int) bool { return true })))
+	assertEq(t, "any", CodeOf(Func("any", func(int) bool { return true },
+		WithCode("any"))))
+	assertEq(t, "", CodeOf(nil))
+}
+
+func TestErrorCode(t *testing.T) {
+	err := ValidOrError[int](3, Min(5))
+	e, ok := err.(Error[int])
+	assertEq(t, true, ok)
+	assertEq(t, "min", e.Code())
+	assertEq(t, map[string]any{"min": 5}, e.Params())
+}
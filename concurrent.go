@@ -0,0 +1,148 @@
+package constraints
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+)
+
+// ValidateAllConcurrently is like the ValidateAll method of set, but
+// the members of set are evaluated concurrently by at most workers
+// goroutines. If workers is zero or negative, runtime.GOMAXPROCS(0)
+// goroutines are used.
+//
+// The violated constraints are returned in the order of the members
+// of set, regardless of the order in which they were evaluated. If ctx
+// is done before all the members are evaluated, including when it's
+// already done, it returns the context's error.
+//
+// It's worth it only for sets with expensive members, e.g., large
+// denylists; the overhead outweighs the gain for cheap constraints.
+//
+// API status: experimental
+func ValidateAllConcurrently[ValueT any, ConstraintT Constraint[ValueT]](
+	ctx context.Context,
+	v ValueT,
+	set ConstraintSet[ValueT, ConstraintT],
+	workers int,
+) ([]Constraint[ValueT], error) {
+	list := set.ConstraintList()
+	valid := make([]bool, len(list))
+	err := forEachConcurrently(ctx, len(list), workers, func(_ context.Context, i int) error {
+		valid[i] = list[i].IsValid(v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var violated []Constraint[ValueT]
+	for i, ok := range valid {
+		if !ok {
+			violated = append(violated, list[i])
+		}
+	}
+	return violated, nil
+}
+
+// WithConcurrency returns a copy of the set whose members are checked
+// concurrently by at most workers goroutines. If workers is zero or
+// negative, runtime.GOMAXPROCS(0) goroutines are used. Use 1 to check
+// the members sequentially, which is the default.
+//
+// The violated constraints are reported in the order of the members
+// regardless of the order in which they were checked. The check stops
+// as soon as a member could not be evaluated or ctx is done.
+func (cs *ContextConstraintSet[ValueT]) WithConcurrency(workers int) *ContextConstraintSet[ValueT] {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	return &ContextConstraintSet[ValueT]{constraints: cs.ConstraintList(), workers: workers}
+}
+
+// checkConcurrently checks v against the members of the set with
+// cs.workers goroutines. It returns the results in the order of
+// the members.
+func (cs *ContextConstraintSet[ValueT]) checkConcurrently(
+	ctx context.Context, v ValueT,
+) ([]error, error) {
+	results := make([]error, len(cs.constraints))
+	err := forEachConcurrently(ctx, len(cs.constraints), cs.workers,
+		func(ctx context.Context, i int) error {
+			c := cs.constraints[i]
+			err := c.Check(ctx, v)
+			if err != nil && !IsViolation(err) {
+				return &EvaluationError{Constraint: c, Err: err}
+			}
+			results[i] = err
+			return nil
+		})
+	return results, err
+}
+
+// forEachConcurrently calls fn for each index from 0 to n-1 with at
+// most workers goroutines. If ctx is already done, fn is not called.
+// Once fn returns an error or ctx is done, no more indexes are
+// dispatched and the context passed to fn is cancelled. It returns
+// the error of the lowest index, or the error of ctx if it was done
+// before all the indexes were dispatched.
+func forEachConcurrently(
+	ctx context.Context,
+	n int,
+	workers int,
+	fn func(ctx context.Context, i int) error,
+) error {
+	// Without this, the dispatch could pick the ready channel over
+	// the done context for every index.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > n {
+		workers = n
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := make([]error, n)
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := fn(runCtx, i); err != nil {
+					errs[i] = err
+					cancel()
+				}
+			}
+		}()
+	}
+
+	dispatched := 0
+dispatch:
+	for ; dispatched < n; dispatched++ {
+		select {
+		case indexes <- dispatched:
+		case <-runCtx.Done():
+			break dispatch
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	for _, err := range errs {
+		// Errors caused by our own cancellation are not interesting.
+		if err != nil && !(errors.Is(err, context.Canceled) && ctx.Err() == nil) {
+			return err
+		}
+	}
+	if dispatched < n {
+		return ctx.Err()
+	}
+	return nil
+}
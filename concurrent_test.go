@@ -0,0 +1,151 @@
+package constraints
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestValidateAllConcurrently(t *testing.T) {
+	set := Set[int](Min(0), Max(10), NoneOf(3, 4), LessThan(5))
+	for _, workers := range []int{0, 1, 2, 8} {
+		violated, err := ValidateAllConcurrently(context.Background(), 11, set, workers)
+		assertEq(t, nil, err)
+		assertEq(t, set.ValidateAll(11), violated)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	// The dispatch must not race with the cancellation.
+	for i := 0; i < 100; i++ {
+		violated, err := ValidateAllConcurrently(ctx, 11, set, 2)
+		assertEq(t, context.Canceled, err)
+		assertEq(t, 0, len(violated))
+	}
+}
+
+func TestContextSetWithConcurrency(t *testing.T) {
+	sleepy := func(desc string, d time.Duration, valid bool) ContextConstraint[string] {
+		return CheckFunc(desc, func(ctx context.Context, v string) (bool, error) {
+			select {
+			case <-time.After(d):
+				return valid, nil
+			case <-ctx.Done():
+				return false, ctx.Err()
+			}
+		})
+	}
+	// The slower members come first so that the violations are
+	// found out of order.
+	c := ContextSet(
+		sleepy("a", 20*time.Millisecond, false),
+		sleepy("b", 10*time.Millisecond, true),
+		sleepy("c", time.Millisecond, false),
+	).WithConcurrency(3)
+
+	err := ValidOrErrorContext[string](context.Background(), "x", c)
+	assertEq(t, "a, c", ViolatedConstraintFromError[string](err).ConstraintDescription())
+}
+
+func TestContextSetWithConcurrencyShortCircuit(t *testing.T) {
+	var started int32
+	failing := errors.New("unreachable")
+	list := []ContextConstraint[int]{
+		CheckFunc("broken", func(ctx context.Context, v int) (bool, error) {
+			atomic.AddInt32(&started, 1)
+			return false, failing
+		}),
+	}
+	for i := 0; i < 100; i++ {
+		list = append(list, CheckFunc(fmt.Sprint(i), func(ctx context.Context, v int) (bool, error) {
+			atomic.AddInt32(&started, 1)
+			<-ctx.Done()
+			return false, ctx.Err()
+		}))
+	}
+	c := ContextSet(list...).WithConcurrency(4)
+
+	err := ValidOrErrorContext[int](context.Background(), 1, c)
+	var ee *EvaluationError
+	assertEq(t, true, errors.As(err, &ee))
+	assertEq(t, "broken", ee.Constraint.ConstraintDescription())
+	assertEq(t, true, errors.Is(err, failing))
+	assertEq(t, true, atomic.LoadInt32(&started) < 101)
+}
+
+// slowDenylist emulates an expensive constraint, e.g., a linear scan of
+// a large denylist.
+func slowDenylist(n int) Constraint[string] {
+	list := make([]string, n)
+	for i := range list {
+		list[i] = fmt.Sprintf("blocked-%d", i)
+	}
+	return Func("not denylisted", func(v string) bool {
+		for _, s := range list {
+			if strings.EqualFold(s, v) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func benchmarkSet() ConstraintSet[string, Constraint[string]] {
+	list := make([]Constraint[string], 8)
+	for i := range list {
+		list[i] = slowDenylist(20000)
+	}
+	return Set(list...)
+}
+
+func BenchmarkSetValidateAllSequential(b *testing.B) {
+	set := benchmarkSet()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		set.ValidateAll("allowed")
+	}
+}
+
+func BenchmarkSetValidateAllConcurrently(b *testing.B) {
+	set := benchmarkSet()
+	ctx := context.Background()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = ValidateAllConcurrently(ctx, "allowed", set, 0)
+	}
+}
+
+// benchmarkContextSet returns a set of lookups which emulate
+// the latency of a remote store.
+func benchmarkContextSet() *ContextConstraintSet[string] {
+	list := make([]ContextConstraint[string], 8)
+	for i := range list {
+		list[i] = CheckFunc("available", func(ctx context.Context, v string) (bool, error) {
+			time.Sleep(time.Millisecond)
+			return true, nil
+		})
+	}
+	return ContextSet(list...)
+}
+
+func BenchmarkContextSetSequential(b *testing.B) {
+	c := benchmarkContextSet()
+	ctx := context.Background()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = c.Check(ctx, "alice")
+	}
+}
+
+func BenchmarkContextSetConcurrently(b *testing.B) {
+	c := benchmarkContextSet().WithConcurrency(8)
+	ctx := context.Background()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = c.Check(ctx, "alice")
+	}
+}
@@ -0,0 +1,379 @@
+package constraints
+
+import "fmt"
+
+// When creates a ConditionalConstraint which validates values against
+// constraint then if they satisfy constraint cond, or against
+// constraint els otherwise. Either of then and els could be nil, in
+// which case the values are considered valid for the branch, but cond
+// is required; When panics if it's nil. It's the equivalent of JSON
+// Schema's if, then and else keywords.
+//
+// When validated with ValidOrError, the error contains the violated
+// constraints of the active branch along with the condition, e.g.,
+// "non-empty when match \"ID\"".
+//
+// API status: experimental
+func When[ValueT any](
+	cond Constraint[ValueT],
+	then Constraint[ValueT],
+	els Constraint[ValueT],
+) ConditionalConstraint[ValueT] {
+	if cond == nil {
+		panic("cond must not be nil")
+	}
+	return &conditionalConstraint[ValueT]{cond: cond, then: then, els: els}
+}
+
+// A ConditionalConstraint is a constraint which validates values with
+// one of its two branches depending on a condition.
+//
+// API status: experimental
+type ConditionalConstraint[ValueT any] interface {
+	Constraint[ValueT]
+
+	// Condition returns the constraint which selects the branch.
+	Condition() Constraint[ValueT]
+
+	// Then returns the constraint used for values which satisfy
+	// the condition. It might be nil.
+	Then() Constraint[ValueT]
+
+	// Else returns the constraint used for values which don't satisfy
+	// the condition. It might be nil.
+	Else() Constraint[ValueT]
+}
+
+type conditionalConstraint[ValueT any] struct {
+	cond Constraint[ValueT]
+	then Constraint[ValueT]
+	els  Constraint[ValueT]
+}
+
+var (
+	_ ConditionalConstraint[string] = &conditionalConstraint[string]{}
+	_ CompositeConstraint[string]   = &conditionalConstraint[string]{}
+	_ KindedConstraint              = &conditionalConstraint[string]{}
+	_ MessageConstraint             = &conditionalConstraint[string]{}
+)
+
+// ConstraintDescription conforms Constraint interface.
+func (c *conditionalConstraint[ValueT]) ConstraintDescription() string {
+	desc := "if " + c.cond.ConstraintDescription()
+	if c.then != nil {
+		desc += " then " + c.then.ConstraintDescription()
+	}
+	if c.els != nil {
+		desc += " else " + c.els.ConstraintDescription()
+	}
+	return desc
+}
+
+// ConstraintKind conforms KindedConstraint interface.
+func (c *conditionalConstraint[ValueT]) ConstraintKind() Kind {
+	return KindWhen
+}
+
+// ConstraintMessage conforms MessageConstraint interface. The ID is
+// "if_then", "if_else" or "if_then_else" depending on the branches
+// the constraint has.
+func (c *conditionalConstraint[ValueT]) ConstraintMessage() Message {
+	id := "if"
+	args := map[string]any{"condition": MessageOf(c.cond)}
+	if c.then != nil {
+		id += "_then"
+		args["then"] = MessageOf(c.then)
+	}
+	if c.els != nil {
+		id += "_else"
+		args["else"] = MessageOf(c.els)
+	}
+	return Message{ID: id, Args: args, Text: c.ConstraintDescription()}
+}
+
+// Condition conforms ConditionalConstraint interface.
+func (c *conditionalConstraint[ValueT]) Condition() Constraint[ValueT] {
+	return c.cond
+}
+
+// Then conforms ConditionalConstraint interface.
+func (c *conditionalConstraint[ValueT]) Then() Constraint[ValueT] {
+	return c.then
+}
+
+// Else conforms ConditionalConstraint interface.
+func (c *conditionalConstraint[ValueT]) Else() Constraint[ValueT] {
+	return c.els
+}
+
+// Children conforms CompositeConstraint interface. It returns
+// the condition followed by the branches which are not nil.
+func (c *conditionalConstraint[ValueT]) Children() []Constraint[ValueT] {
+	children := []Constraint[ValueT]{c.cond}
+	if c.then != nil {
+		children = append(children, c.then)
+	}
+	if c.els != nil {
+		children = append(children, c.els)
+	}
+	return children
+}
+
+// IsValid conforms Constraint interface.
+func (c *conditionalConstraint[ValueT]) IsValid(v ValueT) bool {
+	branch, _ := c.branch(v)
+	return branch == nil || branch.IsValid(v)
+}
+
+// ValidateAll returns the violated constraints of the active branch.
+// Each of them is wrapped in a ConditionalBranchConstraint so that
+// the error reports the condition which activated the branch.
+func (c *conditionalConstraint[ValueT]) ValidateAll(v ValueT) (violated []Constraint[ValueT]) {
+	branch, condMet := c.branch(v)
+	if branch == nil {
+		return nil
+	}
+	if cs, ok := branch.(interface {
+		ValidateAll(ValueT) []Constraint[ValueT]
+	}); ok && cs != nil {
+		for _, vc := range cs.ValidateAll(v) {
+			violated = append(violated, c.wrap(vc, condMet))
+		}
+		return violated
+	}
+	if !branch.IsValid(v) {
+		violated = append(violated, c.wrap(branch, condMet))
+	}
+	return violated
+}
+
+func (c *conditionalConstraint[ValueT]) branch(v ValueT) (branch Constraint[ValueT], condMet bool) {
+	if c.cond.IsValid(v) {
+		return c.then, true
+	}
+	return c.els, false
+}
+
+func (c *conditionalConstraint[ValueT]) wrap(
+	branch Constraint[ValueT], condMet bool,
+) Constraint[ValueT] {
+	return &conditionalBranchConstraint[ValueT]{cond: c.cond, branch: branch, condMet: condMet}
+}
+
+// A ConditionalBranchConstraint is a branch of a ConditionalConstraint
+// which was active for a value. It's found in the errors of
+// ConditionalConstraints.
+//
+// API status: experimental
+type ConditionalBranchConstraint[ValueT any] interface {
+	Constraint[ValueT]
+
+	// Condition returns the condition of the ConditionalConstraint.
+	Condition() Constraint[ValueT]
+
+	// ConditionMet returns true if the branch is the one used for
+	// values which satisfy the condition.
+	ConditionMet() bool
+
+	// Branch returns the constraint of the branch.
+	Branch() Constraint[ValueT]
+}
+
+type conditionalBranchConstraint[ValueT any] struct {
+	cond    Constraint[ValueT]
+	branch  Constraint[ValueT]
+	condMet bool
+}
+
+var (
+	_ ConditionalBranchConstraint[string] = &conditionalBranchConstraint[string]{}
+	_ CompositeConstraint[string]         = &conditionalBranchConstraint[string]{}
+	_ KindedConstraint                    = &conditionalBranchConstraint[string]{}
+	_ MessageConstraint                   = &conditionalBranchConstraint[string]{}
+)
+
+// ConstraintDescription conforms Constraint interface.
+func (c *conditionalBranchConstraint[ValueT]) ConstraintDescription() string {
+	if c.condMet {
+		return c.branch.ConstraintDescription() + " when " + c.cond.ConstraintDescription()
+	}
+	return c.branch.ConstraintDescription() + " unless " + c.cond.ConstraintDescription()
+}
+
+// ConstraintKind conforms KindedConstraint interface.
+func (c *conditionalBranchConstraint[ValueT]) ConstraintKind() Kind {
+	return KindWhenBranch
+}
+
+// ConstraintMessage conforms MessageConstraint interface. The ID is
+// "when" for the branch used for values which satisfy the condition,
+// or "unless" otherwise.
+func (c *conditionalBranchConstraint[ValueT]) ConstraintMessage() Message {
+	id := "unless"
+	if c.condMet {
+		id = "when"
+	}
+	return Message{
+		ID: id,
+		Args: map[string]any{
+			"condition":  MessageOf(c.cond),
+			"constraint": MessageOf(c.branch),
+		},
+		Text: c.ConstraintDescription(),
+	}
+}
+
+// Condition conforms ConditionalBranchConstraint interface.
+func (c *conditionalBranchConstraint[ValueT]) Condition() Constraint[ValueT] {
+	return c.cond
+}
+
+// ConditionMet conforms ConditionalBranchConstraint interface.
+func (c *conditionalBranchConstraint[ValueT]) ConditionMet() bool {
+	return c.condMet
+}
+
+// Branch conforms ConditionalBranchConstraint interface.
+func (c *conditionalBranchConstraint[ValueT]) Branch() Constraint[ValueT] {
+	return c.branch
+}
+
+// Children conforms CompositeConstraint interface. It returns
+// the constraint of the branch.
+func (c *conditionalBranchConstraint[ValueT]) Children() []Constraint[ValueT] {
+	return []Constraint[ValueT]{c.branch}
+}
+
+// IsValid conforms Constraint interface. The condition is assumed to
+// be in the state which activated the branch.
+func (c *conditionalBranchConstraint[ValueT]) IsValid(v ValueT) bool {
+	return c.branch.IsValid(v)
+}
+
+//----
+
+// RequiredIf creates a FieldConstraint which declares a struct as
+// invalid if the field named name has the zero value while the field
+// named otherName equals value, e.g.,
+//
+//	RequiredIf("postal code", func(a Address) string { return a.PostalCode },
+//		"country", func(a Address) string { return a.Country }, "ID")
+//
+// is described as "postal code is required when country is ID".
+//
+// API status: experimental
+func RequiredIf[StructT any, FieldT, OtherT comparable](
+	name string, get func(v StructT) FieldT,
+	otherName string, getOther func(v StructT) OtherT,
+	value OtherT,
+) FieldConstraint[StructT] {
+	return &requiredWhenConstraint[StructT, FieldT, OtherT]{
+		name: name, get: get,
+		otherName: otherName, getOther: getOther,
+		value: value,
+	}
+}
+
+// RequiredWith creates a FieldConstraint which declares a struct as
+// invalid if the field named name has the zero value while the field
+// named otherName doesn't. It's the equivalent of JSON Schema's
+// dependentRequired keyword.
+//
+// API status: experimental
+func RequiredWith[StructT any, FieldT, OtherT comparable](
+	name string, get func(v StructT) FieldT,
+	otherName string, getOther func(v StructT) OtherT,
+) FieldConstraint[StructT] {
+	return &requiredWhenConstraint[StructT, FieldT, OtherT]{
+		name: name, get: get,
+		otherName: otherName, getOther: getOther,
+		present: true,
+	}
+}
+
+type requiredWhenConstraint[StructT any, FieldT, OtherT comparable] struct {
+	name      string
+	get       func(v StructT) FieldT
+	otherName string
+	getOther  func(v StructT) OtherT
+	value     OtherT
+	// present is true if the field is required when the other field
+	// has a value other than the zero value, i.e., RequiredWith.
+	present bool
+}
+
+var (
+	_ FieldConstraint[struct{}] = &requiredWhenConstraint[struct{}, int, int]{}
+	_ KindedConstraint          = &requiredWhenConstraint[struct{}, int, int]{}
+	_ MessageConstraint         = &requiredWhenConstraint[struct{}, int, int]{}
+)
+
+// ConstraintDescription conforms Constraint interface.
+func (fc *requiredWhenConstraint[StructT, FieldT, OtherT]) ConstraintDescription() string {
+	if fc.present {
+		return fc.name + " is required when " + fc.otherName + " is present"
+	}
+	return fmt.Sprintf("%s is required when %s is %v", fc.name, fc.otherName, fc.value)
+}
+
+// ConstraintKind conforms KindedConstraint interface.
+func (fc *requiredWhenConstraint[StructT, FieldT, OtherT]) ConstraintKind() Kind {
+	if fc.present {
+		return KindRequiredWith
+	}
+	return KindRequiredIf
+}
+
+// ConstraintMessage conforms MessageConstraint interface.
+func (fc *requiredWhenConstraint[StructT, FieldT, OtherT]) ConstraintMessage() Message {
+	args := map[string]any{"field": fc.name, "other": fc.otherName}
+	if !fc.present {
+		args["value"] = fc.value
+	}
+	return Message{
+		ID:   string(fc.ConstraintKind()),
+		Args: args,
+		Text: fc.ConstraintDescription(),
+	}
+}
+
+// IsValid conforms Constraint interface.
+func (fc *requiredWhenConstraint[StructT, FieldT, OtherT]) IsValid(v StructT) bool {
+	var zero FieldT
+	return fc.get(v) != zero || !fc.active(v)
+}
+
+// active returns true if the field is required for v.
+func (fc *requiredWhenConstraint[StructT, FieldT, OtherT]) active(v StructT) bool {
+	other := fc.getOther(v)
+	if fc.present {
+		var zero OtherT
+		return other != zero
+	}
+	return other == fc.value
+}
+
+// FieldName conforms FieldConstraint interface.
+func (fc *requiredWhenConstraint[StructT, FieldT, OtherT]) FieldName() string {
+	return fc.name
+}
+
+// FieldValueConstraint conforms FieldConstraint interface. It returns
+// nil as the constraint depends on more than one field.
+func (fc *requiredWhenConstraint[StructT, FieldT, OtherT]) FieldValueConstraint() ConstraintBase {
+	return nil
+}
+
+// FieldError conforms FieldConstraint interface. The error is reported
+// at the path of the field, with the path of the other field as
+// related.
+func (fc *requiredWhenConstraint[StructT, FieldT, OtherT]) FieldError(v StructT) error {
+	if fc.IsValid(v) {
+		return nil
+	}
+	return ErrorList{{
+		Path:    Path(fc.name),
+		Related: []FieldPath{Path(fc.otherName)},
+		Err:     ViolationError[StructT](fc),
+	}}
+}
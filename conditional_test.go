@@ -0,0 +1,95 @@
+package constraints
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestWhen(t *testing.T) {
+	c := When[int](LessThan(0), GreaterThan(-10), Max(100))
+	assertEq(t, "if less than 0 then greater than -10 else max 100",
+		c.ConstraintDescription())
+	assertEq(t, KindWhen, KindOf(c))
+	assertEq(t, "if_then_else", CodeOf(c))
+	assertEq(t, 3, len(c.(CompositeConstraint[int]).Children()))
+	assertEq(t, true, c.IsValid(-5))
+	assertEq(t, false, c.IsValid(-10))
+	assertEq(t, true, c.IsValid(50))
+	assertEq(t, false, c.IsValid(101))
+
+	onlyThen := When[int](LessThan(0), GreaterThan(-10), nil)
+	assertEq(t, "if less than 0 then greater than -10", onlyThen.ConstraintDescription())
+	assertEq(t, true, onlyThen.IsValid(1000))
+}
+
+func TestWhenError(t *testing.T) {
+	c := When[string](Match("ID"),
+		Set[string](Negate[string](Match(""), "non-empty"), Negate[string](Match("ID"), "not ID")),
+		nil)
+
+	assertEq(t, nil, ValidOrError[string]("", When[string](Match("ID"), nil, nil)))
+
+	err := ValidOrError[string]("ID", c)
+	violated := ViolatedConstraintFromError[string](err)
+	assertEq(t, KindSet, KindOf(violated))
+	children := violated.(CompositeConstraint[string]).Children()
+	assertEq(t, 1, len(children))
+	branch := children[0].(ConditionalBranchConstraint[string])
+	assertEq(t, true, branch.ConditionMet())
+	assertEq(t, KindWhenBranch, KindOf(branch))
+	assertEq(t, `not ID when match "ID"`, branch.ConstraintDescription())
+
+	b, err := json.Marshal(ViolationOf[string](branch))
+	assertEq(t, nil, err)
+	assertEq(t, `{"code":"when","params":{"condition":{"code":"match",`+
+		`"description":"match \"ID\"","params":{"value":"ID"}},`+
+		`"constraint":{"description":"not ID","params":{"constraint":{"code":"match",`+
+		`"description":"match \"ID\"","params":{"value":"ID"}}}}},`+
+		`"description":"not ID when match \"ID\""}`,
+		string(b))
+
+	elseBranch := When[int](LessThan(0), nil, Max(100)).(interface {
+		ValidateAll(int) []Constraint[int]
+	}).ValidateAll(101)
+	assertEq(t, 1, len(elseBranch))
+	assertEq(t, "max 100 unless less than 0", elseBranch[0].ConstraintDescription())
+	assertEq(t, "unless", CodeOf(elseBranch[0]))
+}
+
+type testAddressForm struct {
+	Country    string
+	PostalCode string
+	Phone      string
+	PhoneType  string
+}
+
+func TestRequiredIf(t *testing.T) {
+	c := RequiredIf("postal code",
+		func(a testAddressForm) string { return a.PostalCode },
+		"country", func(a testAddressForm) string { return a.Country },
+		"ID")
+	assertEq(t, "postal code is required when country is ID", c.ConstraintDescription())
+	assertEq(t, KindRequiredIf, KindOf(c))
+	assertEq(t, true, c.IsValid(testAddressForm{Country: "SG"}))
+	assertEq(t, true, c.IsValid(testAddressForm{Country: "ID", PostalCode: "10110"}))
+	assertEq(t, false, c.IsValid(testAddressForm{Country: "ID"}))
+
+	errs := Struct(c).FieldErrors(testAddressForm{Country: "ID"})
+	assertEq(t, 1, len(errs))
+	assertEq(t, "postal code", errs[0].Path.String())
+	assertEq(t, "country", errs[0].Related[0].String())
+	assertEq(t, "required_if", errs[0].Err.(Error[testAddressForm]).Code())
+	assertEq(t, map[string]any{"field": "postal code", "other": "country", "value": "ID"},
+		errs[0].Err.(Error[testAddressForm]).Params())
+}
+
+func TestRequiredWith(t *testing.T) {
+	c := RequiredWith("phone type",
+		func(a testAddressForm) string { return a.PhoneType },
+		"phone", func(a testAddressForm) string { return a.Phone })
+	assertEq(t, "phone type is required when phone is present", c.ConstraintDescription())
+	assertEq(t, "required_with", CodeOf(c))
+	assertEq(t, true, c.IsValid(testAddressForm{}))
+	assertEq(t, false, c.IsValid(testAddressForm{Phone: "555"}))
+	assertEq(t, true, c.IsValid(testAddressForm{Phone: "555", PhoneType: "mobile"}))
+}
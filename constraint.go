@@ -59,7 +59,10 @@ type matchConstraint[ValueT comparable] struct {
 }
 
 var (
-	_ Constraint[string] = matchConstraint[string]{}
+	_ Constraint[string]        = matchConstraint[string]{}
+	_ OperandConstraint[string] = matchConstraint[string]{}
+	_ KindedConstraint          = matchConstraint[string]{}
+	_ MessageConstraint         = matchConstraint[string]{}
 )
 
 // ConstraintDescription conforms constraints.Constraint interface.
@@ -67,17 +70,110 @@ func (c matchConstraint[ValueT]) ConstraintDescription() string {
 	return fmt.Sprintf("match %s", valueLiteralString(c.refValue))
 }
 
+// ConstraintKind conforms KindedConstraint interface.
+func (c matchConstraint[ValueT]) ConstraintKind() Kind {
+	return KindMatch
+}
+
+// ConstraintMessage conforms MessageConstraint interface.
+func (c matchConstraint[ValueT]) ConstraintMessage() Message {
+	return Message{
+		ID:   string(KindMatch),
+		Args: map[string]any{"value": c.refValue},
+		Text: c.ConstraintDescription(),
+	}
+}
+
+// Operand conforms OperandConstraint interface.
+func (c matchConstraint[ValueT]) Operand() ValueT {
+	return c.refValue
+}
+
 // IsValid conforms Constraint interface.
 func (c matchConstraint[ValueT]) IsValid(v ValueT) bool {
 	return v == c.refValue
 }
 
-func Negate[ValueT any](c Constraint[ValueT], descOverride string) Constraint[ValueT] {
+// Negate creates a Constraint which will declare a value as valid
+// if c declares it as invalid. If descOverride is empty, the description
+// will be derived from c.
+//
+// The constraint could be given a violation code and a localizable
+// message with the WithCode and WithMessage options.
+func Negate[ValueT any](
+	c Constraint[ValueT],
+	descOverride string,
+	opts ...Option,
+) Constraint[ValueT] {
 	desc := descOverride
 	if desc == "" {
 		desc = "not " + c.ConstraintDescription()
 	}
-	return &constraintFunc[ValueT]{negate: true, desc: desc, fn: c.IsValid}
+	nc := &negateConstraint[ValueT]{desc: desc, custom: descOverride != "", negated: c}
+	nc.options.apply(opts)
+	return nc
+}
+
+type negateConstraint[ValueT any] struct {
+	options
+	desc    string
+	custom  bool
+	negated Constraint[ValueT]
+}
+
+var (
+	_ Constraint[string]          = negateConstraint[string]{}
+	_ CompositeConstraint[string] = negateConstraint[string]{}
+	_ KindedConstraint            = negateConstraint[string]{}
+	_ MessageConstraint           = negateConstraint[string]{}
+	_ CodedConstraint             = negateConstraint[string]{}
+	_ CostedConstraint            = negateConstraint[string]{}
+)
+
+// ConstraintDescription conforms Constraint interface.
+func (c negateConstraint[ValueT]) ConstraintDescription() string {
+	return c.desc
+}
+
+// ConstraintKind conforms KindedConstraint interface.
+func (c negateConstraint[ValueT]) ConstraintKind() Kind {
+	return KindNegate
+}
+
+// ConstraintMessage conforms MessageConstraint interface. If
+// the description was overridden, the message has no ID.
+func (c negateConstraint[ValueT]) ConstraintMessage() Message {
+	msg := Message{
+		ID:   string(KindNegate),
+		Args: map[string]any{"constraint": MessageOf(c.negated)},
+		Text: c.desc,
+	}
+	if c.custom {
+		msg.ID = ""
+	}
+	return c.message(msg)
+}
+
+// ConstraintCode conforms CodedConstraint interface.
+func (c negateConstraint[ValueT]) ConstraintCode() string {
+	return c.code(c.ConstraintMessage().ID)
+}
+
+// ConstraintCost conforms CostedConstraint interface. Unless set with
+// WithCost, it's the cost of the negated constraint.
+func (c negateConstraint[ValueT]) ConstraintCost() Cost {
+	return c.costOr(CostOf(c.negated))
+}
+
+// Children conforms CompositeConstraint interface. It returns
+// the negated constraint.
+func (c negateConstraint[ValueT]) Children() []Constraint[ValueT] {
+	return []Constraint[ValueT]{c.negated}
+}
+
+// IsValid conforms Constraint interface.
+func (c negateConstraint[ValueT]) IsValid(v ValueT) bool {
+	return !c.negated.IsValid(v)
 }
 
 func valueLiteralString(v any) string {
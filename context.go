@@ -0,0 +1,379 @@
+package constraints
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// A ContextConstraint is a constraint which might need to perform I/O
+// to validate a value, e.g., to look up a username in a store. Unlike
+// Constraint, its evaluation could fail or be cancelled.
+//
+// API status: experimental
+type ContextConstraint[ValueT any] interface {
+	ConstraintBase
+
+	// Check validates v. It returns nil if v is valid, a constraint
+	// violation error if v is invalid, or another error if
+	// the validity of v could not be determined, e.g., ctx was
+	// cancelled or the store was unreachable. See IsViolation.
+	Check(ctx context.Context, v ValueT) error
+}
+
+// IsViolation returns true if err reports constraint violations, as
+// opposed to an error which prevented the evaluation of a constraint.
+func IsViolation(err error) bool {
+	var ve violationError
+	return errors.As(err, &ve)
+}
+
+// An EvaluationError is returned by ValidOrErrorContext when
+// the validity of a value could not be determined.
+type EvaluationError struct {
+	// Constraint is the constraint which could not be evaluated.
+	Constraint ConstraintBase
+	Err        error
+}
+
+var _ error = &EvaluationError{}
+
+func (e *EvaluationError) Error() string {
+	if e.Constraint == nil {
+		return "could not evaluate constraint: " + e.Err.Error()
+	}
+	return "could not evaluate " + e.Constraint.ConstraintDescription() +
+		": " + e.Err.Error()
+}
+
+func (e *EvaluationError) Unwrap() error {
+	return e.Err
+}
+
+// ValidOrErrorContext tests the value v against constraint c. It
+// returns nil if v is valid, an Error if v is invalid, or
+// an *EvaluationError if c could not be evaluated.
+//
+// The check is run on the calling goroutine. It relies on c to honour
+// ctx as required by ContextConstraint: a check which ignores ctx
+// could outlive the deadline.
+func ValidOrErrorContext[ValueT any](
+	ctx context.Context, v ValueT, c ContextConstraint[ValueT],
+) error {
+	if c == nil {
+		return ViolationError[ValueT](nil)
+	}
+	if err := ctx.Err(); err != nil {
+		return &EvaluationError{Constraint: c, Err: err}
+	}
+
+	err := c.Check(ctx, v)
+	if err == nil || IsViolation(err) {
+		return err
+	}
+	var ee *EvaluationError
+	if errors.As(err, &ee) {
+		return err
+	}
+	return &EvaluationError{Constraint: c, Err: err}
+}
+
+// ContextViolationError creates a constraint violation error for
+// context constraint c. Implementations of ContextConstraint use it
+// to report invalid values from Check.
+func ContextViolationError[ValueT any](c ContextConstraint[ValueT]) Error[ValueT] {
+	return ViolationError[ValueT](&violatedContextConstraint[ValueT]{c})
+}
+
+// violatedContextConstraint adapts a ContextConstraint to be reported
+// in Error. It takes the kind, the message and the code of the context
+// constraint.
+type violatedContextConstraint[ValueT any] struct {
+	c ContextConstraint[ValueT]
+}
+
+var (
+	_ Constraint[string] = &violatedContextConstraint[string]{}
+	_ KindedConstraint   = &violatedContextConstraint[string]{}
+	_ MessageConstraint  = &violatedContextConstraint[string]{}
+	_ CodedConstraint    = &violatedContextConstraint[string]{}
+)
+
+// ConstraintDescription conforms Constraint interface.
+func (c *violatedContextConstraint[ValueT]) ConstraintDescription() string {
+	return c.c.ConstraintDescription()
+}
+
+// ConstraintKind conforms KindedConstraint interface.
+func (c *violatedContextConstraint[ValueT]) ConstraintKind() Kind {
+	return KindOf(c.c)
+}
+
+// ConstraintMessage conforms MessageConstraint interface.
+func (c *violatedContextConstraint[ValueT]) ConstraintMessage() Message {
+	return MessageOf(c.c)
+}
+
+// ConstraintCode conforms CodedConstraint interface.
+func (c *violatedContextConstraint[ValueT]) ConstraintCode() string {
+	return CodeOf(c.c)
+}
+
+// IsValid conforms Constraint interface. It doesn't perform the check
+// again, which might need I/O and a context: it considers every value
+// as invalid, as the constraint is only found in the errors of values
+// which were found invalid. The constraints adapted with Contextual
+// are evaluated though.
+func (c *violatedContextConstraint[ValueT]) IsValid(v ValueT) bool {
+	if cc, ok := c.c.(*contextualConstraint[ValueT]); ok {
+		return cc.c.IsValid(v)
+	}
+	return false
+}
+
+//----
+
+// Contextual adapts constraint c into a ContextConstraint. The check
+// fails without evaluating c if the context is already done.
+//
+// API status: experimental
+func Contextual[ValueT any](c Constraint[ValueT]) ContextConstraint[ValueT] {
+	return &contextualConstraint[ValueT]{c}
+}
+
+type contextualConstraint[ValueT any] struct {
+	c Constraint[ValueT]
+}
+
+var (
+	_ ContextConstraint[string] = &contextualConstraint[string]{}
+	_ KindedConstraint          = &contextualConstraint[string]{}
+	_ MessageConstraint         = &contextualConstraint[string]{}
+	_ CodedConstraint           = &contextualConstraint[string]{}
+)
+
+// ConstraintDescription conforms Constraint interface.
+func (c *contextualConstraint[ValueT]) ConstraintDescription() string {
+	return c.c.ConstraintDescription()
+}
+
+// ConstraintKind conforms KindedConstraint interface.
+func (c *contextualConstraint[ValueT]) ConstraintKind() Kind {
+	return KindOf(c.c)
+}
+
+// ConstraintMessage conforms MessageConstraint interface.
+func (c *contextualConstraint[ValueT]) ConstraintMessage() Message {
+	return MessageOf(c.c)
+}
+
+// ConstraintCode conforms CodedConstraint interface.
+func (c *contextualConstraint[ValueT]) ConstraintCode() string {
+	return CodeOf(c.c)
+}
+
+// Constraint returns the adapted constraint.
+func (c *contextualConstraint[ValueT]) Constraint() Constraint[ValueT] {
+	return c.c
+}
+
+// Check conforms ContextConstraint interface.
+func (c *contextualConstraint[ValueT]) Check(ctx context.Context, v ValueT) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return ValidOrError(v, c.c)
+}
+
+// CheckFunc creates a ContextConstraint from a function which might
+// fail, e.g., a query to a store. The function returns whether v is
+// valid, or an error if it couldn't tell.
+//
+//	var usernameAvailable = CheckFunc("available",
+//		func(ctx context.Context, username string) (bool, error) {
+//			taken, err := store.UsernameExists(ctx, username)
+//			return !taken, err
+//		})
+//
+// The constraint could be given a violation code and a localizable
+// message with the WithCode and WithMessage options.
+//
+// API status: experimental
+func CheckFunc[ValueT any](
+	desc string,
+	fn func(ctx context.Context, v ValueT) (bool, error),
+	opts ...Option,
+) ContextConstraint[ValueT] {
+	c := &checkFuncConstraint[ValueT]{desc: desc, fn: fn}
+	c.options.apply(opts)
+	return c
+}
+
+type checkFuncConstraint[ValueT any] struct {
+	options
+	desc string
+	fn   func(ctx context.Context, v ValueT) (bool, error)
+}
+
+var (
+	_ ContextConstraint[string] = &checkFuncConstraint[string]{}
+	_ KindedConstraint          = &checkFuncConstraint[string]{}
+	_ MessageConstraint         = &checkFuncConstraint[string]{}
+	_ CodedConstraint           = &checkFuncConstraint[string]{}
+)
+
+// ConstraintDescription conforms Constraint interface.
+func (c *checkFuncConstraint[ValueT]) ConstraintDescription() string {
+	return c.desc
+}
+
+// ConstraintKind conforms KindedConstraint interface.
+func (c *checkFuncConstraint[ValueT]) ConstraintKind() Kind {
+	return KindFunc
+}
+
+// ConstraintMessage conforms MessageConstraint interface.
+func (c *checkFuncConstraint[ValueT]) ConstraintMessage() Message {
+	return c.message(Message{Text: c.desc})
+}
+
+// ConstraintCode conforms CodedConstraint interface.
+func (c *checkFuncConstraint[ValueT]) ConstraintCode() string {
+	return c.code(c.msgID)
+}
+
+// Check conforms ContextConstraint interface.
+func (c *checkFuncConstraint[ValueT]) Check(ctx context.Context, v ValueT) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	valid, err := c.fn(ctx, v)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return ContextViolationError[ValueT](c)
+	}
+	return nil
+}
+
+//----
+
+// ContextSet creates a ContextConstraintSet of the constraints cs.
+//
+// API status: experimental
+func ContextSet[ValueT any](cs ...ContextConstraint[ValueT]) *ContextConstraintSet[ValueT] {
+	copies := make([]ContextConstraint[ValueT], len(cs))
+	copy(copies, cs)
+	return &ContextConstraintSet[ValueT]{constraints: copies}
+}
+
+// A ContextConstraintSet is a set of context constraints. A value is
+// considered valid if every constraint considers it as valid.
+//
+// The constraints are checked in order, unless the set was created
+// with WithConcurrency. If any of them could not be evaluated, the
+// check stops and returns the error.
+type ContextConstraintSet[ValueT any] struct {
+	constraints []ContextConstraint[ValueT]
+	// workers is the number of goroutines used to check the members.
+	// The members are checked sequentially if it's less than 2.
+	workers int
+}
+
+var (
+	_ ContextConstraint[string] = &ContextConstraintSet[string]{}
+	_ KindedConstraint          = &ContextConstraintSet[string]{}
+	_ MessageConstraint         = &ContextConstraintSet[string]{}
+)
+
+// ConstraintDescription conforms Constraint interface.
+func (cs *ContextConstraintSet[ValueT]) ConstraintDescription() string {
+	descs := make([]string, 0, len(cs.constraints))
+	for _, c := range cs.constraints {
+		descs = append(descs, c.ConstraintDescription())
+	}
+	return strings.Join(descs, ", ")
+}
+
+// ConstraintKind conforms KindedConstraint interface.
+func (cs *ContextConstraintSet[ValueT]) ConstraintKind() Kind {
+	return KindSet
+}
+
+// ConstraintMessage conforms MessageConstraint interface.
+func (cs *ContextConstraintSet[ValueT]) ConstraintMessage() Message {
+	msgs := make([]Message, 0, len(cs.constraints))
+	for _, c := range cs.constraints {
+		msgs = append(msgs, MessageOf(c))
+	}
+	return Message{
+		ID:   string(KindSet),
+		Args: map[string]any{"constraints": msgs},
+		Text: cs.ConstraintDescription(),
+	}
+}
+
+// ConstraintList returns a copy of the constraints of the set.
+func (cs *ContextConstraintSet[ValueT]) ConstraintList() []ContextConstraint[ValueT] {
+	copies := make([]ContextConstraint[ValueT], len(cs.constraints))
+	copy(copies, cs.constraints)
+	return copies
+}
+
+// Check conforms ContextConstraint interface. The violation error
+// contains a Set of the violated constraints.
+func (cs *ContextConstraintSet[ValueT]) Check(ctx context.Context, v ValueT) error {
+	var results []error
+	var err error
+	if cs.workers > 1 {
+		results, err = cs.checkConcurrently(ctx, v)
+	} else {
+		results, err = cs.checkSequentially(ctx, v)
+	}
+	if err != nil {
+		return err
+	}
+	var violated []Constraint[ValueT]
+	for _, err := range results {
+		violated = append(violated, violatedConstraints[ValueT](err)...)
+	}
+	if len(violated) > 0 {
+		return ViolationError[ValueT](Set(violated...))
+	}
+	return nil
+}
+
+// checkSequentially checks v against the members of the set one by
+// one. It returns the results in the order of the members.
+func (cs *ContextConstraintSet[ValueT]) checkSequentially(
+	ctx context.Context, v ValueT,
+) ([]error, error) {
+	results := make([]error, len(cs.constraints))
+	for i, c := range cs.constraints {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		err := c.Check(ctx, v)
+		if err != nil && !IsViolation(err) {
+			return nil, &EvaluationError{Constraint: c, Err: err}
+		}
+		results[i] = err
+	}
+	return results, nil
+}
+
+// violatedConstraints returns the violated constraints in violation
+// error err. The members of a violated Set are returned individually.
+func violatedConstraints[ValueT any](err error) []Constraint[ValueT] {
+	c := ViolatedConstraintFromError[ValueT](err)
+	if c == nil {
+		return nil
+	}
+	if KindOf(c) == KindSet {
+		if cc, ok := c.(CompositeConstraint[ValueT]); ok {
+			return cc.Children()
+		}
+	}
+	return []Constraint[ValueT]{c}
+}
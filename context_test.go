@@ -0,0 +1,126 @@
+package constraints
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestContextual(t *testing.T) {
+	c := Contextual[int](Range(1, 10))
+	assertEq(t, "from 1 to 10", c.ConstraintDescription())
+	assertEq(t, KindRange, KindOf(c))
+
+	ctx := context.Background()
+	assertEq(t, nil, ValidOrErrorContext(ctx, 5, c))
+	err := ValidOrErrorContext(ctx, 11, c)
+	assertEq(t, true, IsViolation(err))
+	assertEq(t, "range", err.(Error[int]).Code())
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	err = ValidOrErrorContext(cancelled, 5, c)
+	var ee *EvaluationError
+	assertEq(t, true, errors.As(err, &ee))
+	assertEq(t, false, IsViolation(err))
+	assertEq(t, true, errors.Is(err, context.Canceled))
+}
+
+func TestUnique(t *testing.T) {
+	taken := NewMemoryLookup("alice", "bob")
+	c := Unique[string](taken)
+	ctx := context.Background()
+
+	assertEq(t, nil, ValidOrErrorContext(ctx, "carol", c))
+	err := ValidOrErrorContext(ctx, "alice", c)
+	assertEq(t, true, IsViolation(err))
+	assertEq(t, "required to be unique", err.Error())
+	assertEq(t, KindUnique, KindOf(ViolatedConstraintFromError[string](err)))
+
+	b, err := json.Marshal(err)
+	assertEq(t, nil, err)
+	assertEq(t, `{"code":"unique","description":"unique"}`, string(b))
+
+	taken.Remove("alice")
+	assertEq(t, nil, ValidOrErrorContext(ctx, "alice", c))
+
+	unreachable := errors.New("store unreachable")
+	taken.SetError(unreachable)
+	err = ValidOrErrorContext(ctx, "alice", c)
+	assertEq(t, false, IsViolation(err))
+	assertEq(t, true, errors.Is(err, unreachable))
+	assertEq(t, "could not evaluate unique: store unreachable", err.Error())
+}
+
+func TestExistsIn(t *testing.T) {
+	c := ExistsIn[int](NewMemoryLookup(1, 2, 3), WithCode("unknown_id"))
+	ctx := context.Background()
+	assertEq(t, nil, ValidOrErrorContext(ctx, 2, c))
+	err := ValidOrErrorContext(ctx, 4, c)
+	assertEq(t, true, IsViolation(err))
+	assertEq(t, "unknown_id", err.(Error[int]).Code())
+}
+
+func TestCheckFuncDeadline(t *testing.T) {
+	c := CheckFunc("slow", func(ctx context.Context, v string) (bool, error) {
+		<-ctx.Done()
+		return false, ctx.Err()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := ValidOrErrorContext[string](ctx, "x", c)
+	assertEq(t, true, errors.Is(err, context.DeadlineExceeded))
+	assertEq(t, false, IsViolation(err))
+}
+
+func TestViolatedContextConstraintIsValid(t *testing.T) {
+	calls := 0
+	c := CheckFunc("available", func(ctx context.Context, v string) (bool, error) {
+		calls++
+		return false, nil
+	})
+	err := ValidOrErrorContext[string](context.Background(), "alice", c)
+	violated := ViolatedConstraintFromError[string](err)
+	assertEq(t, false, violated.IsValid("bob"))
+	assertEq(t, 1, calls)
+
+	err = ContextViolationError(Contextual[int](Min(1)))
+	assertEq(t, true, ViolatedConstraintFromError[int](err).IsValid(2))
+	assertEq(t, false, ViolatedConstraintFromError[int](err).IsValid(0))
+}
+
+func TestContextSet(t *testing.T) {
+	denylist := NewMemoryLookup("admin", "root")
+	taken := NewMemoryLookup("alice")
+	c := ContextSet[string](
+		Contextual[string](Negate[string](Match(""), "non-empty")),
+		Unique[string](denylist, WithMessage("allowed", nil)),
+		Unique[string](taken),
+	)
+	assertEq(t, "non-empty, unique, unique", c.ConstraintDescription())
+	assertEq(t, KindSet, KindOf(c))
+	assertEq(t, 3, len(c.ConstraintList()))
+
+	ctx := context.Background()
+	assertEq(t, nil, ValidOrErrorContext[string](ctx, "carol", c))
+
+	err := ValidOrErrorContext[string](ctx, "alice", c)
+	violated := ViolatedConstraintFromError[string](err)
+	assertEq(t, KindSet, KindOf(violated))
+	children := violated.(CompositeConstraint[string]).Children()
+	assertEq(t, 1, len(children))
+	assertEq(t, "unique", CodeOf(children[0]))
+
+	err = ValidOrErrorContext[string](ctx, "admin", c)
+	children = ViolatedConstraintFromError[string](err).(CompositeConstraint[string]).Children()
+	assertEq(t, "allowed", CodeOf(children[0]))
+
+	taken.SetError(errors.New("timeout"))
+	err = ValidOrErrorContext[string](ctx, "", c)
+	var ee *EvaluationError
+	assertEq(t, true, errors.As(err, &ee))
+	assertEq(t, KindUnique, KindOf(ee.Constraint))
+}
@@ -0,0 +1,292 @@
+// Package contextcons provides constraints over context.Context, e.g.,
+// to check the preconditions of an operation:
+//
+//	func doSomethingToSelf(ctx context.Context, userID string) error {
+//		if err := contextcons.Set(
+//			contextcons.NotNil,
+//			contextcons.UserOnly,
+//			contextcons.UserMatches(userID),
+//		).ValidOrError(ctx); err != nil {
+//			return err
+//		}
+//		// ...
+//	}
+//
+// The violations are reported with constraints.Error so that
+// authorization preconditions could be handled like any other
+// constraint violations.
+//
+// The principal constraints extract the principal of the context
+// with a PrincipalSource. The package-level ones use the principal
+// stored with WithPrincipal; use NewPrincipalConstraints to plug
+// another source in.
+//
+// API status: experimental
+package contextcons
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rez-go/constraints"
+)
+
+// Kinds of the constraints in this package.
+const (
+	KindNotNil            constraints.Kind = "context.not_nil"
+	KindHasDeadline       constraints.Kind = "context.has_deadline"
+	KindDeadlineRemaining constraints.Kind = "context.deadline_remaining"
+	KindPrincipalKind     constraints.Kind = "context.principal_kind"
+	KindPrincipalID       constraints.Kind = "context.principal_id"
+	KindScope             constraints.Kind = "context.scope"
+)
+
+// A Constraint is a constraint over contexts.
+type Constraint = constraints.Constraint[context.Context]
+
+var (
+	// NotNil declares a context as valid if it's not nil.
+	NotNil Constraint = &contextConstraint{
+		kind: KindNotNil,
+		desc: "non-nil context",
+		fn:   func(ctx context.Context) bool { return true },
+	}
+
+	// HasDeadline declares a context as valid if it has a deadline.
+	HasDeadline Constraint = &contextConstraint{
+		kind: KindHasDeadline,
+		desc: "has deadline",
+		fn: func(ctx context.Context) bool {
+			_, ok := ctx.Deadline()
+			return ok
+		},
+	}
+
+	// UserOnly declares a context as valid if its principal is a user.
+	UserOnly = defaultPrincipals.UserOnly()
+)
+
+// DeadlineAtLeast creates a Constraint which declares a context as
+// valid if it has a deadline which is at least d away.
+func DeadlineAtLeast(d time.Duration) Constraint {
+	return &contextConstraint{
+		kind: KindDeadlineRemaining,
+		desc: fmt.Sprintf("deadline at least %v away", d),
+		args: map[string]any{"min": d},
+		fn: func(ctx context.Context) bool {
+			deadline, ok := ctx.Deadline()
+			return ok && time.Until(deadline) >= d
+		},
+	}
+}
+
+// PrincipalKindIs creates a Constraint which declares a context as
+// valid if the kind of its principal is kind.
+func PrincipalKindIs(kind string) Constraint {
+	return defaultPrincipals.KindIs(kind)
+}
+
+// PrincipalIDMatches creates a Constraint which declares a context as
+// valid if the ID of its principal is id.
+func PrincipalIDMatches(id string) Constraint {
+	return defaultPrincipals.IDMatches(id)
+}
+
+// UserMatches creates a Constraint which declares a context as valid
+// if its principal is the user identified by id.
+func UserMatches(id string) Constraint {
+	return defaultPrincipals.UserMatches(id)
+}
+
+// HasScope creates a Constraint which declares a context as valid if
+// its principal has been granted scope.
+func HasScope(scope string) Constraint {
+	return defaultPrincipals.HasScope(scope)
+}
+
+var defaultPrincipals = NewPrincipalConstraints(PrincipalSourceFunc(PrincipalFromContext))
+
+// PrincipalConstraints creates the constraints over the principal of
+// contexts, with the principal extracted by a PrincipalSource.
+type PrincipalConstraints struct {
+	source PrincipalSource
+}
+
+// NewPrincipalConstraints creates a PrincipalConstraints which
+// extracts the principals with source.
+func NewPrincipalConstraints(source PrincipalSource) *PrincipalConstraints {
+	return &PrincipalConstraints{source: source}
+}
+
+// KindIs creates a Constraint which declares a context as valid if
+// the kind of its principal is kind.
+func (pc *PrincipalConstraints) KindIs(kind string) Constraint {
+	return &contextConstraint{
+		kind: KindPrincipalKind,
+		desc: "principal is " + kind,
+		args: map[string]any{"kind": kind},
+		fn: pc.principalFunc(func(p Principal) bool {
+			return p.PrincipalKind() == kind
+		}),
+	}
+}
+
+// UserOnly creates a Constraint which declares a context as valid if
+// its principal is a user.
+func (pc *PrincipalConstraints) UserOnly() Constraint {
+	return pc.KindIs(PrincipalKindUser)
+}
+
+// IDMatches creates a Constraint which declares a context as valid if
+// the ID of its principal is id.
+func (pc *PrincipalConstraints) IDMatches(id string) Constraint {
+	return &contextConstraint{
+		kind: KindPrincipalID,
+		desc: fmt.Sprintf("principal ID %q", id),
+		args: map[string]any{"id": id},
+		fn: pc.principalFunc(func(p Principal) bool {
+			return p.PrincipalID() == id
+		}),
+	}
+}
+
+// UserMatches creates a Constraint which declares a context as valid
+// if its principal is the user identified by id.
+func (pc *PrincipalConstraints) UserMatches(id string) Constraint {
+	return constraints.Set(pc.UserOnly(), pc.IDMatches(id))
+}
+
+// HasScope creates a Constraint which declares a context as valid if
+// its principal has been granted scope.
+func (pc *PrincipalConstraints) HasScope(scope string) Constraint {
+	return &contextConstraint{
+		kind: KindScope,
+		desc: fmt.Sprintf("has scope %q", scope),
+		args: map[string]any{"scope": scope},
+		fn: pc.principalFunc(func(p Principal) bool {
+			for _, s := range p.PrincipalScopes() {
+				if s == scope {
+					return true
+				}
+			}
+			return false
+		}),
+	}
+}
+
+// principalFunc returns a function which evaluates fn with
+// the principal of the context. Contexts without principal are
+// considered as invalid.
+func (pc *PrincipalConstraints) principalFunc(
+	fn func(p Principal) bool,
+) func(ctx context.Context) bool {
+	return func(ctx context.Context) bool {
+		if pc.source == nil {
+			return false
+		}
+		p := pc.source.ContextPrincipal(ctx)
+		return p != nil && fn(p)
+	}
+}
+
+var (
+	_ constraints.KindedConstraint  = &contextConstraint{}
+	_ constraints.MessageConstraint = &contextConstraint{}
+)
+
+type contextConstraint struct {
+	kind constraints.Kind
+	desc string
+	args map[string]any
+	// fn is called with non-nil contexts only.
+	fn func(ctx context.Context) bool
+}
+
+// ConstraintDescription conforms constraints.Constraint interface.
+func (c *contextConstraint) ConstraintDescription() string {
+	return c.desc
+}
+
+// ConstraintKind conforms constraints.KindedConstraint interface.
+func (c *contextConstraint) ConstraintKind() constraints.Kind {
+	return c.kind
+}
+
+// ConstraintMessage conforms constraints.MessageConstraint interface.
+func (c *contextConstraint) ConstraintMessage() constraints.Message {
+	return constraints.Message{ID: string(c.kind), Args: c.args, Text: c.desc}
+}
+
+// IsValid conforms constraints.Constraint interface. Nil contexts
+// are invalid for all the constraints.
+func (c *contextConstraint) IsValid(ctx context.Context) bool {
+	return ctx != nil && c.fn(ctx)
+}
+
+//----
+
+// Set creates a ConstraintSet of the constraints cs.
+func Set(cs ...Constraint) *ConstraintSet {
+	return &ConstraintSet{set: constraints.Set(cs...)}
+}
+
+// A ConstraintSet is a set of constraints over contexts. A context is
+// considered valid if every constraint considers it as valid.
+type ConstraintSet struct {
+	set constraints.ConstraintSet[context.Context, Constraint]
+}
+
+var (
+	_ constraints.ConstraintSet[context.Context, Constraint] = &ConstraintSet{}
+	_ constraints.CompositeConstraint[context.Context]       = &ConstraintSet{}
+	_ constraints.KindedConstraint                           = &ConstraintSet{}
+	_ constraints.MessageConstraint                          = &ConstraintSet{}
+)
+
+// ValidOrError tests ctx against the constraints. It returns nil if
+// ctx is valid, otherwise it returns a constraints.Error which
+// contains the violated constraints.
+func (s *ConstraintSet) ValidOrError(ctx context.Context) error {
+	return constraints.ValidOrError[context.Context](ctx, s.set)
+}
+
+// ConstraintDescription conforms constraints.Constraint interface.
+func (s *ConstraintSet) ConstraintDescription() string {
+	return s.set.ConstraintDescription()
+}
+
+// ConstraintKind conforms constraints.KindedConstraint interface.
+func (s *ConstraintSet) ConstraintKind() constraints.Kind {
+	return constraints.KindSet
+}
+
+// ConstraintMessage conforms constraints.MessageConstraint interface.
+func (s *ConstraintSet) ConstraintMessage() constraints.Message {
+	return constraints.MessageOf(s.set)
+}
+
+// Children conforms constraints.CompositeConstraint interface.
+func (s *ConstraintSet) Children() []Constraint {
+	return s.set.ConstraintList()
+}
+
+// ConstraintList conforms constraints.ConstraintSet interface.
+func (s *ConstraintSet) ConstraintList() []Constraint {
+	return s.set.ConstraintList()
+}
+
+// IsValid conforms constraints.Constraint interface.
+func (s *ConstraintSet) IsValid(ctx context.Context) bool {
+	return s.set.IsValid(ctx)
+}
+
+// Validate conforms constraints.ConstraintSet interface.
+func (s *ConstraintSet) Validate(ctx context.Context) Constraint {
+	return s.set.Validate(ctx)
+}
+
+// ValidateAll conforms constraints.ConstraintSet interface.
+func (s *ConstraintSet) ValidateAll(ctx context.Context) []Constraint {
+	return s.set.ValidateAll(ctx)
+}
@@ -0,0 +1,95 @@
+package contextcons
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rez-go/constraints"
+	ctesting "github.com/rez-go/constraints/internal/testing"
+)
+
+var assertEq = ctesting.AssertEq
+
+func TestNotNil(t *testing.T) {
+	assertEq(t, true, NotNil.IsValid(context.Background()))
+	assertEq(t, false, NotNil.IsValid(nil))
+	assertEq(t, KindNotNil, constraints.KindOf(NotNil))
+}
+
+func TestDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+
+	assertEq(t, false, HasDeadline.IsValid(context.Background()))
+	assertEq(t, true, HasDeadline.IsValid(ctx))
+
+	c := DeadlineAtLeast(time.Minute)
+	assertEq(t, "deadline at least 1m0s away", c.ConstraintDescription())
+	assertEq(t, true, c.IsValid(ctx))
+	assertEq(t, false, DeadlineAtLeast(2*time.Hour).IsValid(ctx))
+	assertEq(t, false, c.IsValid(context.Background()))
+	assertEq(t, map[string]any{"min": time.Minute}, constraints.ParamsOf(c))
+}
+
+func TestPrincipal(t *testing.T) {
+	ctx := WithPrincipal(context.Background(), BasicPrincipal{
+		ID:     "alice",
+		Kind:   PrincipalKindUser,
+		Scopes: []string{"profile:read"},
+	})
+
+	assertEq(t, true, UserOnly.IsValid(ctx))
+	assertEq(t, false, UserOnly.IsValid(context.Background()))
+	assertEq(t, false, PrincipalKindIs(PrincipalKindService).IsValid(ctx))
+	assertEq(t, true, PrincipalIDMatches("alice").IsValid(ctx))
+	assertEq(t, true, UserMatches("alice").IsValid(ctx))
+	assertEq(t, false, UserMatches("bob").IsValid(ctx))
+	assertEq(t, true, HasScope("profile:read").IsValid(ctx))
+	assertEq(t, false, HasScope("profile:write").IsValid(ctx))
+	assertEq(t, `has scope "profile:write"`, HasScope("profile:write").ConstraintDescription())
+}
+
+func TestPrincipalSource(t *testing.T) {
+	type tokenKey struct{}
+	pc := NewPrincipalConstraints(PrincipalSourceFunc(func(ctx context.Context) Principal {
+		if sub, ok := ctx.Value(tokenKey{}).(string); ok {
+			return BasicPrincipal{ID: sub, Kind: PrincipalKindService}
+		}
+		return nil
+	}))
+	ctx := context.WithValue(context.Background(), tokenKey{}, "billing")
+
+	assertEq(t, true, pc.KindIs(PrincipalKindService).IsValid(ctx))
+	assertEq(t, true, pc.IDMatches("billing").IsValid(ctx))
+	assertEq(t, false, pc.UserOnly().IsValid(ctx))
+	// The package-level constraints don't see the custom source.
+	assertEq(t, false, PrincipalIDMatches("billing").IsValid(ctx))
+}
+
+func TestSet(t *testing.T) {
+	ctx := WithPrincipal(context.Background(), BasicPrincipal{ID: "bob", Kind: PrincipalKindUser})
+
+	s := Set(NotNil, UserOnly, UserMatches("bob"))
+	assertEq(t, nil, s.ValidOrError(ctx))
+	assertEq(t, constraints.KindSet, constraints.KindOf(s))
+	assertEq(t, 3, len(s.Children()))
+
+	err := Set(NotNil, UserOnly, PrincipalIDMatches("alice")).ValidOrError(ctx)
+	var cerr constraints.Error[context.Context]
+	assertEq(t, true, errors.As(err, &cerr))
+	assertEq(t, `principal ID "alice"`, cerr.ViolatedConstraint().ConstraintDescription())
+
+	b, err := json.Marshal(err)
+	assertEq(t, nil, err)
+	assertEq(t, `{"code":"set","description":"principal ID \"alice\"",`+
+		`"violations":[{"code":"context.principal_id","params":{"id":"alice"},`+
+		`"description":"principal ID \"alice\""}]}`,
+		string(b))
+
+	err = s.ValidOrError(nil)
+	assertEq(t, "non-nil context, principal is user, principal is user, principal ID \"bob\"",
+		constraints.ViolatedConstraintFromError[context.Context](err).ConstraintDescription())
+}
@@ -0,0 +1,79 @@
+package contextcons
+
+import "context"
+
+// A Principal is the entity on whose behalf a context is processed,
+// e.g., a signed-in user or a service account.
+type Principal interface {
+	// PrincipalID returns the identifier of the principal, e.g.,
+	// a user ID.
+	PrincipalID() string
+
+	// PrincipalKind returns the kind of the principal, e.g.,
+	// PrincipalKindUser.
+	PrincipalKind() string
+
+	// PrincipalScopes returns the scopes granted to the principal,
+	// e.g., "profile:write".
+	PrincipalScopes() []string
+}
+
+// Well-known principal kinds.
+const (
+	PrincipalKindUser    = "user"
+	PrincipalKindService = "service"
+)
+
+// A BasicPrincipal is a simple implementation of Principal.
+type BasicPrincipal struct {
+	ID     string
+	Kind   string
+	Scopes []string
+}
+
+var _ Principal = BasicPrincipal{}
+
+// PrincipalID conforms Principal interface.
+func (p BasicPrincipal) PrincipalID() string { return p.ID }
+
+// PrincipalKind conforms Principal interface.
+func (p BasicPrincipal) PrincipalKind() string { return p.Kind }
+
+// PrincipalScopes conforms Principal interface.
+func (p BasicPrincipal) PrincipalScopes() []string { return p.Scopes }
+
+// A PrincipalSource extracts the Principal of contexts. Applications
+// implement it to plug their authentication layer in, e.g., to read
+// the claims of a token stored in the context.
+type PrincipalSource interface {
+	// ContextPrincipal returns the principal of ctx, or nil if ctx
+	// has none. ctx is never nil.
+	ContextPrincipal(ctx context.Context) Principal
+}
+
+// PrincipalSourceFunc is an adapter to allow the use of ordinary
+// functions as PrincipalSource.
+type PrincipalSourceFunc func(ctx context.Context) Principal
+
+var _ PrincipalSource = PrincipalSourceFunc(nil)
+
+// ContextPrincipal conforms PrincipalSource interface.
+func (fn PrincipalSourceFunc) ContextPrincipal(ctx context.Context) Principal {
+	return fn(ctx)
+}
+
+type principalContextKey struct{}
+
+// WithPrincipal returns a copy of ctx which carries principal p. The
+// principal could be retrieved with PrincipalFromContext, which is
+// the source used by the package-level constraints.
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, p)
+}
+
+// PrincipalFromContext returns the principal stored in ctx with
+// WithPrincipal, or nil if there's none.
+func PrincipalFromContext(ctx context.Context) Principal {
+	p, _ := ctx.Value(principalContextKey{}).(Principal)
+	return p
+}
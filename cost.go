@@ -0,0 +1,65 @@
+package constraints
+
+// A Cost is a hint of how expensive it is to evaluate a constraint.
+// Only the relative order of the costs matters; see ValidateWith.
+//
+// API status: experimental
+type Cost int
+
+// Reference costs.
+const (
+	// CostCheap is the cost of constant-time checks, e.g., comparisons
+	// and length checks.
+	CostCheap Cost = 1
+	// CostDefault is the cost assumed for constraints which don't
+	// provide a hint, e.g., those created with Func.
+	CostDefault Cost = 10
+	// CostExpensive is the cost of checks like regular expression
+	// matching.
+	CostExpensive Cost = 100
+)
+
+// CostedConstraint is implemented by constraints which provide
+// a hint of their evaluation cost.
+type CostedConstraint interface {
+	ConstraintBase
+
+	// ConstraintCost returns the cost hint of the constraint.
+	ConstraintCost() Cost
+}
+
+// WithCost sets the cost hint of the constraint. See CostOf.
+func WithCost(cost Cost) Option {
+	return func(o *options) {
+		o.cost = cost
+	}
+}
+
+// CostOf returns the cost hint of constraint c.
+//
+// If c doesn't implement CostedConstraint, the cost of a composite
+// constraint is the sum of the costs of its children, comparisons are
+// cheap, and anything else costs CostDefault.
+//
+// API status: experimental
+func CostOf[ValueT any](c Constraint[ValueT]) Cost {
+	if cc, ok := c.(CostedConstraint); ok {
+		return cc.ConstraintCost()
+	}
+	if cc, ok := c.(CompositeConstraint[ValueT]); ok {
+		var cost Cost
+		for _, child := range cc.Children() {
+			cost += CostOf(child)
+		}
+		return cost
+	}
+	switch KindOf(c) {
+	case KindMatch, KindOneOf, KindNoneOf, KindRange,
+		KindMin, KindMax, KindEqualTo, KindNotEqualTo,
+		KindLessThan, KindLessThanOrEqualTo,
+		KindGreaterThan, KindGreaterThanOrEqualTo,
+		KindFields, KindRequiredIf, KindRequiredWith:
+		return CostCheap
+	}
+	return CostDefault
+}
@@ -0,0 +1,179 @@
+package constraints
+
+import (
+	"fmt"
+
+	typecons "golang.org/x/exp/constraints"
+)
+
+// FieldsRelation creates a FieldConstraint which declares a struct as
+// valid if relation op holds between two of its fields, e.g.,
+//
+//	FieldsRelation("end date", func(e Event) int64 { return e.End },
+//		RelOpGreater,
+//		"start date", func(e Event) int64 { return e.Start })
+//
+// is described as "end date greater than start date". The violation
+// is reported at the path of the first field, with the path of the
+// other field as related.
+//
+// API status: experimental
+func FieldsRelation[StructT any, FieldT typecons.Ordered](
+	name string, get func(v StructT) FieldT,
+	op RelOp,
+	otherName string, getOther func(v StructT) FieldT,
+) FieldConstraint[StructT] {
+	return &crossFieldConstraint[StructT, FieldT]{
+		name: name, get: get,
+		op:        op,
+		otherName: otherName, getOther: getOther,
+		holds: func(a, b FieldT) bool {
+			return relOpHolds(op, a, b)
+		},
+	}
+}
+
+// FieldsRelationFunc is like FieldsRelation but for types which are
+// not ordered by the language, e.g., time.Time. The compare function
+// must return a negative number, zero or a positive number if a is
+// less than, equal to or greater than b respectively.
+//
+// API status: experimental
+func FieldsRelationFunc[StructT, FieldT any](
+	name string, get func(v StructT) FieldT,
+	op RelOp,
+	otherName string, getOther func(v StructT) FieldT,
+	compare func(a, b FieldT) int,
+) FieldConstraint[StructT] {
+	return &crossFieldConstraint[StructT, FieldT]{
+		name: name, get: get,
+		op:        op,
+		otherName: otherName, getOther: getOther,
+		holds: func(a, b FieldT) bool {
+			return op.holds(compare(a, b))
+		},
+	}
+}
+
+// FieldsEqual creates a FieldConstraint which declares a struct as
+// valid if two of its fields are equal, e.g., a password and its
+// confirmation.
+//
+// API status: experimental
+func FieldsEqual[StructT any, FieldT comparable](
+	name string, get func(v StructT) FieldT,
+	otherName string, getOther func(v StructT) FieldT,
+) FieldConstraint[StructT] {
+	return &crossFieldConstraint[StructT, FieldT]{
+		name: name, get: get,
+		op:        RelOpEqual,
+		otherName: otherName, getOther: getOther,
+		holds: func(a, b FieldT) bool {
+			return a == b
+		},
+	}
+}
+
+// FieldsOrdered creates a FieldConstraint which declares a struct as
+// valid if the value of the field named name is less than or equal to
+// the value of the field named otherName, e.g., the lower and the
+// upper limits of a range.
+//
+// API status: experimental
+func FieldsOrdered[StructT any, FieldT typecons.Ordered](
+	name string, get func(v StructT) FieldT,
+	otherName string, getOther func(v StructT) FieldT,
+) FieldConstraint[StructT] {
+	return FieldsRelation(name, get, RelOpLessOrEqual, otherName, getOther)
+}
+
+// A CrossFieldConstraint is a FieldConstraint which relates two fields
+// of a struct.
+//
+// API status: experimental
+type CrossFieldConstraint[StructT any] interface {
+	FieldConstraint[StructT]
+
+	// OtherFieldName returns the name of the field which the field
+	// is compared against.
+	OtherFieldName() string
+
+	// RelOp returns the relation which must hold between the fields.
+	RelOp() RelOp
+}
+
+type crossFieldConstraint[StructT, FieldT any] struct {
+	name      string
+	get       func(v StructT) FieldT
+	op        RelOp
+	otherName string
+	getOther  func(v StructT) FieldT
+	holds     func(a, b FieldT) bool
+}
+
+var (
+	_ CrossFieldConstraint[struct{}] = &crossFieldConstraint[struct{}, int]{}
+	_ KindedConstraint               = &crossFieldConstraint[struct{}, int]{}
+	_ MessageConstraint              = &crossFieldConstraint[struct{}, int]{}
+)
+
+// ConstraintDescription conforms Constraint interface.
+func (fc *crossFieldConstraint[StructT, FieldT]) ConstraintDescription() string {
+	return fc.name + " " + fmt.Sprintf(fc.op.StringFormat(), fc.otherName)
+}
+
+// ConstraintKind conforms KindedConstraint interface.
+func (fc *crossFieldConstraint[StructT, FieldT]) ConstraintKind() Kind {
+	return KindFields
+}
+
+// ConstraintMessage conforms MessageConstraint interface. The ID is
+// the kind of the relation prefixed with "fields.", e.g.,
+// "fields.greater_than".
+func (fc *crossFieldConstraint[StructT, FieldT]) ConstraintMessage() Message {
+	return Message{
+		ID:   string(KindFields) + "." + string(fc.op.Kind()),
+		Args: map[string]any{"field": fc.name, "other": fc.otherName},
+		Text: fc.ConstraintDescription(),
+	}
+}
+
+// IsValid conforms Constraint interface.
+func (fc *crossFieldConstraint[StructT, FieldT]) IsValid(v StructT) bool {
+	return fc.holds(fc.get(v), fc.getOther(v))
+}
+
+// FieldName conforms FieldConstraint interface.
+func (fc *crossFieldConstraint[StructT, FieldT]) FieldName() string {
+	return fc.name
+}
+
+// FieldValueConstraint conforms FieldConstraint interface. It returns
+// nil as the constraint depends on more than one field.
+func (fc *crossFieldConstraint[StructT, FieldT]) FieldValueConstraint() ConstraintBase {
+	return nil
+}
+
+// OtherFieldName conforms CrossFieldConstraint interface.
+func (fc *crossFieldConstraint[StructT, FieldT]) OtherFieldName() string {
+	return fc.otherName
+}
+
+// RelOp conforms CrossFieldConstraint interface.
+func (fc *crossFieldConstraint[StructT, FieldT]) RelOp() RelOp {
+	return fc.op
+}
+
+// FieldError conforms FieldConstraint interface. The error is reported
+// at the path of the field, with the path of the other field as
+// related.
+func (fc *crossFieldConstraint[StructT, FieldT]) FieldError(v StructT) error {
+	if fc.IsValid(v) {
+		return nil
+	}
+	return ErrorList{{
+		Path:    Path(fc.name),
+		Related: []FieldPath{Path(fc.otherName)},
+		Err:     ViolationError[StructT](fc),
+	}}
+}
@@ -0,0 +1,97 @@
+package constraints
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+type testEvent struct {
+	Start time.Time
+	End   time.Time
+	Min   int
+	Max   int
+}
+
+type testSignUp struct {
+	Password        string
+	PasswordConfirm string
+}
+
+func TestFieldsRelation(t *testing.T) {
+	c := FieldsRelation("max",
+		func(e testEvent) int { return e.Max },
+		RelOpGreater,
+		"min", func(e testEvent) int { return e.Min })
+	assertEq(t, "max greater than min", c.ConstraintDescription())
+	assertEq(t, KindFields, KindOf(c))
+	assertEq(t, "fields.greater_than", CodeOf(c))
+	assertEq(t, true, c.IsValid(testEvent{Min: 1, Max: 2}))
+	assertEq(t, false, c.IsValid(testEvent{Min: 2, Max: 2}))
+
+	cfc := c.(CrossFieldConstraint[testEvent])
+	assertEq(t, "min", cfc.OtherFieldName())
+	assertEq(t, RelOpGreater, cfc.RelOp())
+	assertEq(t, nil, cfc.FieldValueConstraint())
+}
+
+func compareTime(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+	return 0
+}
+
+func TestFieldsRelationFunc(t *testing.T) {
+	c := FieldsRelationFunc("end date",
+		func(e testEvent) time.Time { return e.End },
+		RelOpGreater,
+		"start date", func(e testEvent) time.Time { return e.Start },
+		compareTime)
+	assertEq(t, "end date greater than start date", c.ConstraintDescription())
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	assertEq(t, true, c.IsValid(testEvent{Start: start, End: start.Add(time.Hour)}))
+	assertEq(t, false, c.IsValid(testEvent{Start: start, End: start}))
+}
+
+func TestFieldsEqual(t *testing.T) {
+	c := FieldsEqual("password confirmation",
+		func(s testSignUp) string { return s.PasswordConfirm },
+		"password", func(s testSignUp) string { return s.Password })
+	assertEq(t, "password confirmation equals password", c.ConstraintDescription())
+	assertEq(t, true, c.IsValid(testSignUp{Password: "s3cret", PasswordConfirm: "s3cret"}))
+	assertEq(t, false, c.IsValid(testSignUp{Password: "s3cret", PasswordConfirm: "secret"}))
+}
+
+func TestFieldsOrderedInStruct(t *testing.T) {
+	sc := Struct(
+		Field[testEvent, int]("min",
+			func(e testEvent) int { return e.Min },
+			Min(0)),
+		FieldsOrdered("min",
+			func(e testEvent) int { return e.Min },
+			"max", func(e testEvent) int { return e.Max }),
+	)
+	assertEq(t, "min: min 0; min less than or equal to max", sc.ConstraintDescription())
+	assertEq(t, true, sc.IsValid(testEvent{Min: 1, Max: 1}))
+
+	type wrapper struct{ Range testEvent }
+	wc := Struct(Field[wrapper, testEvent]("range",
+		func(w wrapper) testEvent { return w.Range }, sc))
+	errs := wc.FieldErrors(wrapper{Range: testEvent{Min: 2, Max: 1}})
+	assertEq(t, 1, len(errs))
+	assertEq(t, "range.min", errs[0].Path.String())
+	assertEq(t, 1, len(errs[0].Related))
+	assertEq(t, "range.max", errs[0].Related[0].String())
+
+	b, err := json.Marshal(errs)
+	assertEq(t, nil, err)
+	assertEq(t, `[{"field":"range.min","pointer":"/range/min","related":["range.max"],`+
+		`"code":"fields.less_than_or_equal_to","params":{"field":"min","other":"max"},`+
+		`"description":"min less than or equal to max"}]`,
+		string(b))
+}
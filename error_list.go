@@ -0,0 +1,176 @@
+package constraints
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// An ErrorList accumulates the errors of multiple fields so that
+// all the problems of a value could be reported at once.
+//
+// The zero value is an empty list ready to use.
+//
+// API status: experimental
+type ErrorList []*FieldError
+
+var (
+	_ error          = ErrorList{}
+	_ json.Marshaler = ErrorList{}
+)
+
+// Add appends err as the error of the field at path. If err is nil,
+// it does nothing. If err is an ErrorList, e.g., from the validation
+// of a nested value, its errors are appended with their paths prefixed
+// with path.
+func (l *ErrorList) Add(path FieldPath, err error) {
+	if err == nil {
+		return
+	}
+	var nested ErrorList
+	if errors.As(err, &nested) {
+		for _, fe := range nested {
+			*l = append(*l, fe.withPrefix(path))
+		}
+		return
+	}
+	var fe *FieldError
+	if errors.As(err, &fe) {
+		*l = append(*l, fe.withPrefix(path))
+		return
+	}
+	*l = append(*l, &FieldError{Path: path, Err: err})
+}
+
+// Err returns the list as an error, or nil if the list is empty.
+func (l ErrorList) Err() error {
+	if len(l) == 0 {
+		return nil
+	}
+	return l
+}
+
+func (l ErrorList) Error() string {
+	switch len(l) {
+	case 0:
+		return "no errors"
+	case 1:
+		return l[0].Error()
+	}
+	msgs := make([]string, 0, len(l))
+	for _, fe := range l {
+		msgs = append(msgs, fe.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the field errors. It allows errors.Is and errors.As
+// to inspect the errors of every field.
+func (l ErrorList) Unwrap() []error {
+	errs := make([]error, 0, len(l))
+	for _, fe := range l {
+		errs = append(errs, fe)
+	}
+	return errs
+}
+
+// Is reports whether any error in the list matches target. Like As,
+// it's for the versions of package errors which don't support
+// Unwrap() []error, i.e., before Go 1.20.
+func (l ErrorList) Is(target error) bool {
+	for _, fe := range l {
+		if errors.Is(fe, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first error in the list which matches target. It's for
+// the versions of package errors which don't support Unwrap() []error.
+func (l ErrorList) As(target any) bool {
+	for _, fe := range l {
+		if errors.As(fe, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON conforms json.Marshaler interface. The result is an array
+// of the encoded FieldErrors.
+func (l ErrorList) MarshalJSON() ([]byte, error) {
+	if l == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]*FieldError(l))
+}
+
+// ValidateField validates the value v of the field at path against
+// constraint c. The violation, if any, is added to list l. It returns
+// true if the value is valid.
+//
+// If c reports errors for the parts of values, e.g., a Struct or
+// the stdtypes.Each constraint of a slice, the errors are added with
+// their paths under path. This applies to the members of a Set too,
+// e.g., Set(stdtypes.SliceMaxLength(3), stdtypes.Each(c)): the other
+// violated members are reported together at path.
+func ValidateField[ValueT any](
+	l *ErrorList,
+	path FieldPath,
+	v ValueT,
+	c Constraint[ValueT],
+) bool {
+	if fc, ok := c.(fieldErrorsConstraint[ValueT]); ok {
+		errs := fc.FieldErrors(v)
+		l.Add(path, errs.Err())
+		return len(errs) == 0
+	}
+	if hasFieldErrors(c) {
+		var violated []Constraint[ValueT]
+		var nested ErrorList
+		for _, m := range c.(CompositeConstraint[ValueT]).Children() {
+			if hasFieldErrors(m) {
+				ValidateField(&nested, nil, v, m)
+			} else if !m.IsValid(v) {
+				violated = append(violated, m)
+			}
+		}
+		if len(violated) > 0 {
+			l.Add(path, ViolationError[ValueT](Set(violated...)))
+		}
+		l.Add(path, nested.Err())
+		return len(violated) == 0 && len(nested) == 0
+	}
+	err := ValidOrError(v, c)
+	l.Add(path, err)
+	return err == nil
+}
+
+// fieldErrorsConstraint is implemented by constraints which report
+// the errors of the parts of values, e.g., the elements of a slice,
+// with their paths.
+type fieldErrorsConstraint[ValueT any] interface {
+	FieldErrors(v ValueT) ErrorList
+}
+
+// hasFieldErrors returns true if c reports errors with paths, or is
+// a Set which has such a constraint among its members, recursively.
+func hasFieldErrors[ValueT any](c Constraint[ValueT]) bool {
+	if _, ok := c.(fieldErrorsConstraint[ValueT]); ok {
+		return true
+	}
+	if KindOf(c) != KindSet {
+		return false
+	}
+	cc, ok := c.(CompositeConstraint[ValueT])
+	if !ok {
+		return false
+	}
+	for _, m := range cc.Children() {
+		if hasFieldErrors(m) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package constraints
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestFieldPath(t *testing.T) {
+	p := Path("user").Field("addresses").Index(2).Field("zip")
+	assertEq(t, "user.addresses[2].zip", p.String())
+	assertEq(t, "/user/addresses/2/zip", p.JSONPointer())
+	p = Path("labels").Key("app/name")
+	assertEq(t, `labels["app/name"]`, p.String())
+	assertEq(t, "/labels/app~1name", p.JSONPointer())
+}
+
+func TestErrorList(t *testing.T) {
+	var errs ErrorList
+	assertEq(t, nil, errs.Err())
+
+	assertEq(t, true, ValidateField[int](&errs, Path("age"), 20, Min(13)))
+	assertEq(t, false, ValidateField[int](&errs, Path("age"), 10, Min(13)))
+	errs.Add(Path("body"), io.ErrUnexpectedEOF)
+
+	var nested ErrorList
+	ValidateField[string](&nested, Path("zip"), "", Match("12345"))
+	errs.Add(Path("user", "addresses").Index(2), nested.Err())
+
+	err := errs.Err()
+	assertEq(t, 3, len(errs))
+	assertEq(t, `age: required to be min 13; body: unexpected EOF; `+
+		`user.addresses[2].zip: required to be match "12345"`, err.Error())
+	assertEq(t, true, errors.Is(err, io.ErrUnexpectedEOF))
+	// Without Unwrap() []error, i.e., before Go 1.20.
+	assertEq(t, true, errs.Is(io.ErrUnexpectedEOF))
+	assertEq(t, false, errs.Is(io.EOF))
+
+	var fe *FieldError
+	assertEq(t, true, errors.As(err, &fe))
+	assertEq(t, "age", fe.Path.String())
+	assertEq(t, Constraint[int](Min(13)), ViolatedConstraintFromError[int](fe))
+
+	b, _ := json.Marshal(err)
+	assertEq(t, `[`+
+		`{"field":"age","pointer":"/age","code":"min","params":{"min":13},"description":"min 13"},`+
+		`{"field":"body","pointer":"/body","description":"unexpected EOF"},`+
+		`{"field":"user.addresses[2].zip","pointer":"/user/addresses/2/zip",`+
+		`"code":"match","params":{"value":"12345"},"description":"match \"12345\""}]`,
+		string(b))
+}
@@ -1,6 +1,10 @@
 package constraints
 
-import "errors"
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+)
 
 // An Error is a specialized error which describes constraint violation(s).
 type Error[
@@ -8,6 +12,14 @@ type Error[
 ] interface {
 	error
 	ViolatedConstraint() Constraint[ValueT]
+
+	// Code returns the machine-readable code of the violated constraint.
+	// See CodeOf.
+	Code() string
+
+	// Params returns the parameters of the violated constraint.
+	// See ParamsOf.
+	Params() map[string]any
 }
 
 // ViolationError creates a new constraint violation error.
@@ -32,7 +44,10 @@ func ViolatedConstraintFromError[
 }
 
 var (
-	_ Error[any] = &requirementError[any]{}
+	_ Error[any]             = &requirementError[any]{}
+	_ violationError         = &requirementError[any]{}
+	_ json.Marshaler         = &requirementError[any]{}
+	_ encoding.TextMarshaler = &requirementError[any]{}
 )
 
 type requirementError[ValueT any] struct {
@@ -56,3 +71,36 @@ func (e *requirementError[ValueT]) ViolatedConstraint() Constraint[ValueT] {
 	}
 	return nil
 }
+
+func (e *requirementError[ValueT]) Code() string {
+	if e != nil {
+		return CodeOf(e.violated)
+	}
+	return ""
+}
+
+func (e *requirementError[ValueT]) Params() map[string]any {
+	if e != nil {
+		return ParamsOf(e.violated)
+	}
+	return nil
+}
+
+// MarshalJSON conforms json.Marshaler interface. The result is
+// the Violation of the violated constraint.
+func (e *requirementError[ValueT]) MarshalJSON() ([]byte, error) {
+	if e == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(e.violation())
+}
+
+func (e *requirementError[ValueT]) violation() Violation {
+	return ViolationOf(e.violated)
+}
+
+// MarshalText conforms encoding.TextMarshaler interface. The result is
+// the same as the result of Error.
+func (e *requirementError[ValueT]) MarshalText() ([]byte, error) {
+	return []byte(e.Error()), nil
+}
@@ -0,0 +1,55 @@
+package constraints
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestErrorMarshalJSON(t *testing.T) {
+	err := ValidOrError[int](3, Min(5))
+	b, _ := json.Marshal(err)
+	assertEq(t, `{"code":"min","params":{"min":5},"description":"min 5"}`, string(b))
+}
+
+func TestErrorMarshalJSONSet(t *testing.T) {
+	c := Set[int](
+		Min(5),
+		Max(10),
+		Any[int](Match(1), Match(2)),
+		Negate[int](Match(3), "not three", WithCode("not_three")),
+	)
+	err := ValidOrError[int](3, c)
+	b, _ := json.Marshal(err)
+	assertEq(t, `{"code":"set","description":"min 5, match 1 or match 2, not three","violations":[`+
+		`{"code":"min","params":{"min":5},"description":"min 5"},`+
+		`{"code":"any","params":{"constraints":[`+
+		`{"code":"match","description":"match 1","params":{"value":1}},`+
+		`{"code":"match","description":"match 2","params":{"value":2}}]},`+
+		`"description":"match 1 or match 2"},`+
+		`{"code":"not_three","params":{"constraint":`+
+		`{"code":"match","description":"match 3","params":{"value":3}}},`+
+		`"description":"not three"}]}`,
+		string(b))
+}
+
+func TestErrorMarshalJSONNestedCode(t *testing.T) {
+	known := Func("known ID", func(int) bool { return false },
+		WithMessage("id.known", nil), WithCode("unknown_id"))
+	c := Set[int](Min(5), Any[int](Match(1), known))
+	err := ValidOrError[int](3, c)
+	b, _ := json.Marshal(err)
+	assertEq(t, `{"code":"set","description":"min 5, match 1 or known ID","violations":[`+
+		`{"code":"min","params":{"min":5},"description":"min 5"},`+
+		`{"code":"any","params":{"constraints":[`+
+		`{"code":"match","description":"match 1","params":{"value":1}},`+
+		`{"code":"unknown_id","description":"known ID"}]},`+
+		`"description":"match 1 or known ID"}]}`,
+		string(b))
+	assertEq(t, "unknown_id", CodeOf(known))
+}
+
+func TestErrorMarshalText(t *testing.T) {
+	err := ValidOrError[int](3, Min(5))
+	b, _ := err.(interface{ MarshalText() ([]byte, error) }).MarshalText()
+	assertEq(t, "required to be min 5", string(b))
+}
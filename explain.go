@@ -0,0 +1,158 @@
+package constraints
+
+import "strings"
+
+// An Outcome is the result of the evaluation of a constraint in
+// an Explanation.
+type Outcome string
+
+// Outcomes of the evaluation of a constraint.
+const (
+	OutcomeValid   Outcome = "valid"
+	OutcomeInvalid Outcome = "invalid"
+	// OutcomeSkipped is the outcome of constraints which were not
+	// evaluated, e.g., the inactive branch of a ConditionalConstraint.
+	OutcomeSkipped Outcome = "skipped"
+)
+
+// An Explanation describes how a value was evaluated against
+// a constraint and, recursively, against the constraint's children.
+// It's created with Explain.
+//
+// An Explanation could be encoded to JSON, or rendered as indented
+// text with String.
+//
+// API status: experimental
+type Explanation struct {
+	Kind        Kind    `json:"kind,omitempty"`
+	Code        string  `json:"code,omitempty"`
+	Description string  `json:"description"`
+	Outcome     Outcome `json:"outcome"`
+	// Role is the role of the constraint in its parent, e.g.,
+	// "condition" for the condition of a ConditionalConstraint. It's
+	// empty for plain members.
+	Role     string         `json:"role,omitempty"`
+	Children []*Explanation `json:"children,omitempty"`
+}
+
+// Valid returns true if the outcome is OutcomeValid.
+func (e *Explanation) Valid() bool {
+	return e.Outcome == OutcomeValid
+}
+
+// String renders the explanation as indented text, one constraint per
+// line, e.g.,
+//
+//	[invalid] all of
+//	  [valid] min length 6
+//	  [invalid] any of
+//	    [invalid] match "admin"
+//	    [invalid] prefix "user_"
+func (e *Explanation) String() string {
+	var sb strings.Builder
+	e.write(&sb, 0)
+	return sb.String()
+}
+
+func (e *Explanation) write(sb *strings.Builder, depth int) {
+	sb.WriteString(strings.Repeat("  ", depth))
+	sb.WriteString("[" + string(e.Outcome) + "] ")
+	if e.Role != "" {
+		sb.WriteString(e.Role + ": ")
+	}
+	switch {
+	case e.Kind == KindSet && len(e.Children) > 0:
+		sb.WriteString("all of")
+	case e.Kind == KindAny && len(e.Children) > 0:
+		sb.WriteString("any of")
+	default:
+		sb.WriteString(e.Description)
+	}
+	sb.WriteString("\n")
+	for _, child := range e.Children {
+		child.write(sb, depth+1)
+	}
+}
+
+// Explain evaluates v against constraint c and all of its descendants,
+// e.g., each alternative of an Any, and returns the outcomes as
+// a tree. Unlike ValidOrError, it doesn't stop at the first level of
+// violated constraints, which makes it useful for debugging.
+//
+// The branch of a ConditionalConstraint which was not taken, and
+// the dependent of a DependentConstraint whose prerequisites were not
+// satisfied, are reported as skipped.
+//
+// API status: experimental
+func Explain[ValueT any](v ValueT, c Constraint[ValueT]) *Explanation {
+	if c == nil {
+		return &Explanation{Outcome: OutcomeInvalid}
+	}
+	e := explanationOf(c, OutcomeInvalid)
+	if c.IsValid(v) {
+		e.Outcome = OutcomeValid
+	}
+
+	switch tc := c.(type) {
+	case ConditionalConstraint[ValueT]:
+		cond := explainRole(v, tc.Condition(), "condition")
+		e.Children = append(e.Children, cond)
+		for _, branch := range []struct {
+			c      Constraint[ValueT]
+			role   string
+			active bool
+		}{
+			{tc.Then(), "then", cond.Valid()},
+			{tc.Else(), "else", !cond.Valid()},
+		} {
+			if branch.c == nil {
+				continue
+			}
+			if branch.active {
+				e.Children = append(e.Children, explainRole(v, branch.c, branch.role))
+			} else {
+				e.Children = append(e.Children, skipped(branch.c, branch.role))
+			}
+		}
+		return e
+	case DependentConstraint[ValueT]:
+		satisfied := true
+		for _, p := range tc.Prerequisites() {
+			pe := explainRole(v, p, "prerequisite")
+			satisfied = satisfied && pe.Valid()
+			e.Children = append(e.Children, pe)
+		}
+		if satisfied {
+			e.Children = append(e.Children, explainRole(v, tc.Dependent(), "dependent"))
+		} else {
+			e.Children = append(e.Children, skipped(tc.Dependent(), "dependent"))
+		}
+		return e
+	case CompositeConstraint[ValueT]:
+		for _, child := range tc.Children() {
+			e.Children = append(e.Children, Explain(v, child))
+		}
+	}
+	return e
+}
+
+func explainRole[ValueT any](v ValueT, c Constraint[ValueT], role string) *Explanation {
+	e := Explain(v, c)
+	e.Role = role
+	return e
+}
+
+func skipped(c ConstraintBase, role string) *Explanation {
+	e := explanationOf(c, OutcomeSkipped)
+	e.Role = role
+	return e
+}
+
+func explanationOf(c ConstraintBase, outcome Outcome) *Explanation {
+	return &Explanation{
+		Kind:        KindOf(c),
+		Code:        CodeOf(c),
+		Description: c.ConstraintDescription(),
+		Outcome:     outcome,
+	}
+}
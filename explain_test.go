@@ -0,0 +1,54 @@
+package constraints
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	c := Set[string](
+		Negate[string](Match(""), "non-empty"),
+		Any[string](OneOf("admin", "root"), Min("m")),
+	)
+
+	e := Explain[string]("alice", c)
+	assertEq(t, false, e.Valid())
+	assertEq(t, `[invalid] all of
+  [valid] non-empty
+    [invalid] match ""
+  [invalid] any of
+    [invalid] one of [admin, root]
+    [invalid] min m
+`, e.String())
+
+	assertEq(t, true, Explain[string]("root", c).Valid())
+
+	b, err := json.Marshal(Explain[string]("", c.ConstraintList()[0]))
+	assertEq(t, nil, err)
+	assertEq(t, `{"kind":"not","description":"non-empty","outcome":"invalid",`+
+		`"children":[{"kind":"match","code":"match","description":"match \"\"","outcome":"valid"}]}`,
+		string(b))
+}
+
+func TestExplainSkipped(t *testing.T) {
+	nonZero := Negate[int](Match(0), "non-zero")
+	c := Set[int](
+		When[int](LessThan(0), GreaterThan(-10), Max(100)),
+		DependsOn(Func("divides 12", func(v int) bool { return 12%v == 0 }), nonZero),
+	)
+
+	assertEq(t, `[valid] all of
+  [valid] if less than 0 then greater than -10 else max 100
+    [invalid] condition: less than 0
+    [skipped] then: greater than -10
+    [valid] else: max 100
+  [valid] divides 12
+    [invalid] prerequisite: non-zero
+      [valid] match 0
+    [skipped] dependent: divides 12
+`, Explain[int](0, c).String())
+}
+
+func TestExplainNil(t *testing.T) {
+	assertEq(t, OutcomeInvalid, Explain[int](1, nil).Outcome)
+}
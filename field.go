@@ -0,0 +1,168 @@
+package constraints
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// A FieldPath locates a field in a value, e.g., user.addresses[2].zip.
+//
+// API status: experimental
+type FieldPath []PathElement
+
+// A PathElement is an element of a FieldPath. It's either a field name,
+// a slice index or a map key.
+type PathElement struct {
+	Name    string
+	Index   int
+	IsIndex bool
+	Key     string
+	IsKey   bool
+}
+
+// Path creates a FieldPath from field names.
+func Path(names ...string) FieldPath {
+	p := make(FieldPath, 0, len(names))
+	for _, name := range names {
+		p = append(p, PathElement{Name: name})
+	}
+	return p
+}
+
+// Field returns a new path with a field name appended.
+func (p FieldPath) Field(name string) FieldPath {
+	return p.append(PathElement{Name: name})
+}
+
+// Index returns a new path with a slice index appended.
+func (p FieldPath) Index(i int) FieldPath {
+	return p.append(PathElement{Index: i, IsIndex: true})
+}
+
+// Key returns a new path with a map key appended.
+func (p FieldPath) Key(k string) FieldPath {
+	return p.append(PathElement{Key: k, IsKey: true})
+}
+
+// Concat returns a new path with other appended.
+func (p FieldPath) Concat(other FieldPath) FieldPath {
+	return p.append(other...)
+}
+
+func (p FieldPath) append(elems ...PathElement) FieldPath {
+	result := make(FieldPath, 0, len(p)+len(elems))
+	result = append(result, p...)
+	return append(result, elems...)
+}
+
+// String returns the path in dotted notation, e.g.,
+// user.addresses[2].zip or labels["app"].
+func (p FieldPath) String() string {
+	var sb strings.Builder
+	for i, e := range p {
+		switch {
+		case e.IsIndex:
+			sb.WriteString("[" + strconv.Itoa(e.Index) + "]")
+		case e.IsKey:
+			sb.WriteString("[" + strconv.Quote(e.Key) + "]")
+		default:
+			if i > 0 {
+				sb.WriteByte('.')
+			}
+			sb.WriteString(e.Name)
+		}
+	}
+	return sb.String()
+}
+
+// JSONPointer returns the path as a JSON Pointer (RFC 6901), e.g.,
+// /user/addresses/2/zip.
+func (p FieldPath) JSONPointer() string {
+	var sb strings.Builder
+	for _, e := range p {
+		sb.WriteByte('/')
+		switch {
+		case e.IsIndex:
+			sb.WriteString(strconv.Itoa(e.Index))
+		case e.IsKey:
+			sb.WriteString(escapeJSONPointer(e.Key))
+		default:
+			sb.WriteString(escapeJSONPointer(e.Name))
+		}
+	}
+	return sb.String()
+}
+
+func escapeJSONPointer(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
+
+// A FieldError is an error of a field of a value, e.g., a constraint
+// violation error of a field of a request body.
+type FieldError struct {
+	Path FieldPath
+	// Related contains the paths of the other fields involved in
+	// the error, e.g., the field which a field is compared against.
+	Related []FieldPath
+	Err     error
+}
+
+var (
+	_ error          = &FieldError{}
+	_ json.Marshaler = &FieldError{}
+)
+
+func (e *FieldError) Error() string {
+	if e.Err == nil {
+		return e.Path.String() + ": <nil>"
+	}
+	return e.Path.String() + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// withPrefix returns a copy of e with its paths prefixed with prefix.
+func (e *FieldError) withPrefix(prefix FieldPath) *FieldError {
+	var related []FieldPath
+	for _, p := range e.Related {
+		related = append(related, prefix.Concat(p))
+	}
+	return &FieldError{Path: prefix.Concat(e.Path), Related: related, Err: e.Err}
+}
+
+// MarshalJSON conforms json.Marshaler interface. The result contains
+// the path as "field" and "pointer", the related paths, if any, as
+// "related", along with the fields of the Violation if Err is
+// a constraint violation error.
+func (e *FieldError) MarshalJSON() ([]byte, error) {
+	doc := struct {
+		Field   string   `json:"field"`
+		Pointer string   `json:"pointer"`
+		Related []string `json:"related,omitempty"`
+		Violation
+	}{
+		Field:   e.Path.String(),
+		Pointer: e.Path.JSONPointer(),
+	}
+	for _, p := range e.Related {
+		doc.Related = append(doc.Related, p.String())
+	}
+	var ve violationError
+	if errors.As(e.Err, &ve) {
+		doc.Violation = ve.violation()
+	} else if e.Err != nil {
+		doc.Violation.Description = e.Err.Error()
+	}
+	return json.Marshal(doc)
+}
+
+// violationError is implemented by errors which describe constraint
+// violations.
+type violationError interface {
+	error
+	violation() Violation
+}
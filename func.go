@@ -17,35 +17,56 @@ type ValidatorFunc[ValueT any] func(i ValueT) bool
 //
 //	var usernamePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9]+$`)
 //	var usernameConstraint = Func("username", usernamePattern.MatchString)
+//
+// The constraint could be given a violation code and a localizable
+// message with the WithCode and WithMessage options, and a cost hint
+// with the WithCost option.
 func Func[
 	ValueT any,
-](desc string, fn func(v ValueT) bool) Constraint[ValueT] {
-	return &constraintFunc[ValueT]{
-		negate: false,
-		desc:   desc,
-		fn:     fn,
+](desc string, fn func(v ValueT) bool, opts ...Option) Constraint[ValueT] {
+	c := &constraintFunc[ValueT]{
+		desc: desc,
+		fn:   fn,
 	}
+	c.options.apply(opts)
+	return c
 }
 
 var (
 	_ Constraint[int64] = &constraintFunc[int64]{}
 	_ Constraint[int64] = constraintFunc[int64]{}
+	_ KindedConstraint  = constraintFunc[int64]{}
+	_ MessageConstraint = constraintFunc[int64]{}
+	_ CodedConstraint   = constraintFunc[int64]{}
+	_ CostedConstraint  = constraintFunc[int64]{}
 )
 
 type constraintFunc[ValueT any] struct {
-	negate bool
-	desc   string
-	fn     ValidatorFunc[ValueT]
+	options
+	desc string
+	fn   ValidatorFunc[ValueT]
 }
 
 func (c constraintFunc[ValueT]) ConstraintDescription() string {
 	return c.desc
 }
 
+func (c constraintFunc[ValueT]) ConstraintKind() Kind {
+	return KindFunc
+}
+
+func (c constraintFunc[ValueT]) ConstraintMessage() Message {
+	return c.message(Message{Text: c.desc})
+}
+
+func (c constraintFunc[ValueT]) ConstraintCode() string {
+	return c.code(c.msgID)
+}
+
+func (c constraintFunc[ValueT]) ConstraintCost() Cost {
+	return c.costOr(CostDefault)
+}
+
 func (c constraintFunc[ValueT]) IsValid(v ValueT) bool {
-	result := c.fn(v)
-	if c.negate {
-		return !result
-	}
-	return result
+	return c.fn(v)
 }
@@ -0,0 +1,441 @@
+// Package html5 converts constraints into HTML5 constraint validation
+// attributes of input elements.
+//
+// See https://developer.mozilla.org/en-US/docs/Web/Guide/HTML/HTML5/Constraint_validation
+//
+// API status: experimental
+package html5
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rez-go/constraints"
+	"github.com/rez-go/constraints/stdtypes"
+)
+
+// Attributes holds the constraint validation attributes of an input
+// element. Empty strings and negative lengths denote absent attributes.
+type Attributes struct {
+	Required  bool
+	MinLength int
+	MaxLength int
+	Min       string
+	Max       string
+	Step      string
+	Pattern   string
+	Title     string
+}
+
+// FromConstraint creates Attributes from constraint c. The constraints
+// in c which couldn't be expressed as attributes are returned as
+// unsupported. If unsupported is empty, the browser validation is
+// equivalent to constraints.ValidOrError, except for the lengths of
+// some strings, see below.
+//
+// Browsers count minlength and maxlength in UTF-16 code units, so only
+// the lengths in runes are expressed as such. They still differ for
+// the runes outside the Basic Multilingual Plane, e.g., most emoji,
+// which browsers count as two: stdtypes.StringRuneMaxLength(3) accepts
+// "😀😀" but the browser rejects it. These lengths are not reported as
+// unsupported, so values with such runes should still be validated
+// with constraints.ValidOrError.
+//
+// String patterns are used only if they're anchored on both ends and
+// written in the syntax which is common to package regexp and
+// JavaScript, e.g., without flags like (?i) and without POSIX classes.
+//
+// The title attribute is derived from the description of c.
+func FromConstraint[ValueT any](
+	c constraints.Constraint[ValueT],
+) (attrs Attributes, unsupported []constraints.ConstraintBase) {
+	b := &builder[ValueT]{
+		attrs: Attributes{MinLength: -1, MaxLength: -1},
+	}
+	switch reflect.TypeOf((*ValueT)(nil)).Elem().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		b.numeric = true
+		b.attrs.Step = "1"
+	case reflect.Float32, reflect.Float64:
+		b.numeric = true
+		b.attrs.Step = "any"
+	case reflect.String:
+		b.text = true
+	}
+	if c != nil {
+		b.add(c)
+		b.attrs.Title = c.ConstraintDescription()
+	}
+	return b.attrs, b.unsupported
+}
+
+// Map returns the attributes as a map of attribute names to their
+// values. Boolean attributes have empty values.
+func (attrs Attributes) Map() map[string]string {
+	m := map[string]string{}
+	for _, a := range attrs.list() {
+		m[a[0]] = a[1]
+	}
+	return m
+}
+
+// HTMLAttr renders the attributes for use in html/template, e.g.,
+//
+//	<input name="username" {{.UsernameAttrs.HTMLAttr}}>
+func (attrs Attributes) HTMLAttr() template.HTMLAttr {
+	var sb strings.Builder
+	for i, a := range attrs.list() {
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(a[0])
+		if a[0] != "required" {
+			sb.WriteString(`="`)
+			sb.WriteString(html.EscapeString(a[1]))
+			sb.WriteByte('"')
+		}
+	}
+	return template.HTMLAttr(sb.String())
+}
+
+func (attrs Attributes) list() [][2]string {
+	var list [][2]string
+	if attrs.Required {
+		list = append(list, [2]string{"required", ""})
+	}
+	if attrs.MinLength >= 0 {
+		list = append(list, [2]string{"minlength", strconv.Itoa(attrs.MinLength)})
+	}
+	if attrs.MaxLength >= 0 {
+		list = append(list, [2]string{"maxlength", strconv.Itoa(attrs.MaxLength)})
+	}
+	for _, a := range [][2]string{
+		{"min", attrs.Min},
+		{"max", attrs.Max},
+		{"step", attrs.Step},
+		{"pattern", attrs.Pattern},
+		{"title", attrs.Title},
+	} {
+		if a[1] != "" {
+			list = append(list, a)
+		}
+	}
+	return list
+}
+
+type builder[ValueT any] struct {
+	attrs       Attributes
+	unsupported []constraints.ConstraintBase
+	numeric     bool
+	text        bool
+	// Parsed bounds to keep the tighter one when there are multiple
+	// bounds constraints.
+	min, max float64
+}
+
+func (b *builder[ValueT]) add(c constraints.Constraint[ValueT]) {
+	if !b.addSupported(c) {
+		b.unsupported = append(b.unsupported, c)
+	}
+}
+
+func (b *builder[ValueT]) addSupported(c constraints.Constraint[ValueT]) bool {
+	if b.text && constraints.ConstraintBase(c) == constraints.ConstraintBase(stdtypes.NonEmptyString) {
+		b.attrs.Required = true
+		return true
+	}
+
+	switch constraints.KindOf(c) {
+	case constraints.KindSet:
+		if cc, ok := c.(constraints.CompositeConstraint[ValueT]); ok {
+			for _, child := range cc.Children() {
+				b.add(child)
+			}
+			return true
+		}
+	case constraints.KindMin, constraints.KindMax,
+		constraints.KindLessThan, constraints.KindLessThanOrEqualTo,
+		constraints.KindGreaterThan, constraints.KindGreaterThanOrEqualTo,
+		constraints.KindRange:
+		if bc, ok := c.(constraints.BoundedConstraint[ValueT]); ok && b.numeric {
+			return b.addBounds(bc.Bounds())
+		}
+	case stdtypes.KindRuneLength, stdtypes.KindRuneLengthMin,
+		stdtypes.KindRuneLengthMax, stdtypes.KindRuneLengthRange:
+		// Only the lengths in runes are checked. The lengths in bytes
+		// and in graphemes, e.g., stdtypes.StringMaxLength, differ from
+		// what browsers count as soon as there are non-ASCII characters.
+		if lc, ok := c.(stdtypes.LengthUnitConstraint); ok && b.text &&
+			lc.LengthUnit() == stdtypes.LengthUnitRunes {
+			min, max := lc.LengthBounds()
+			if min >= 0 && min > b.attrs.MinLength {
+				b.attrs.MinLength = min
+			}
+			if max >= 0 && (b.attrs.MaxLength < 0 || max < b.attrs.MaxLength) {
+				b.attrs.MaxLength = max
+			}
+			// Browsers don't check minlength for empty values.
+			if min > 0 {
+				b.attrs.Required = true
+			}
+			return true
+		}
+	case constraints.KindMatch:
+		// A match of the empty string couldn't be expressed as a pattern
+		// as browsers don't check patterns for empty values.
+		if oc, ok := c.(constraints.OperandConstraint[ValueT]); ok && b.text &&
+			fmt.Sprint(oc.Operand()) != "" {
+			return b.setPattern(regexp.QuoteMeta(fmt.Sprint(oc.Operand())))
+		}
+	case constraints.KindOneOf:
+		if oc, ok := c.(constraints.OptionsConstraint[ValueT]); ok && b.text {
+			opts := oc.Options()
+			quoted := make([]string, 0, len(opts))
+			for _, o := range opts {
+				quoted = append(quoted, regexp.QuoteMeta(fmt.Sprint(o)))
+			}
+			return b.setPattern(strings.Join(quoted, "|"))
+		}
+	case stdtypes.KindStringPrefix:
+		if oc, ok := c.(constraints.OperandConstraint[string]); ok {
+			return b.setPattern(regexp.QuoteMeta(oc.Operand()) + ".*")
+		}
+	case stdtypes.KindStringSuffix:
+		if oc, ok := c.(constraints.OperandConstraint[string]); ok {
+			return b.setPattern(".*" + regexp.QuoteMeta(oc.Operand()))
+		}
+	case stdtypes.KindStringRunesIn:
+		if rc, ok := c.(stdtypes.RuneClassConstraint); ok {
+			if p := rc.RuneClass().Regexp() + "*"; portablePattern(p) {
+				return b.setPattern(p)
+			}
+		}
+	case stdtypes.KindStringPattern:
+		if oc, ok := c.(constraints.OperandConstraint[string]); ok {
+			// The pattern attribute is always anchored, as a whole. We
+			// could only use patterns which are anchored on both ends,
+			// without alternations at the top level, e.g., "^a|b$" which
+			// is "(^a)|(b$)".
+			p := oc.Operand()
+			if strings.HasPrefix(p, "^") && strings.HasSuffix(p, "$") &&
+				!strings.HasSuffix(p, `\$`) && portablePattern(p[1:len(p)-1]) {
+				return b.setPattern(p[1 : len(p)-1])
+			}
+		}
+	}
+	return false
+}
+
+func (b *builder[ValueT]) setPattern(pattern string) bool {
+	if b.attrs.Pattern != "" {
+		return false
+	}
+	b.attrs.Pattern = pattern
+	// Browsers don't check the pattern of empty values.
+	if !regexp.MustCompile("^(?:" + pattern + ")$").MatchString("") {
+		b.attrs.Required = true
+	}
+	return true
+}
+
+// portablePattern returns true if the regular expression p has the same
+// meaning in package regexp and in JavaScript, as the pattern attribute,
+// and has no alternation at the top level. It's conservative: it only
+// allows literals, escaped punctuation, \d and \w, ".", simple
+// character classes, non-capturing and capturing groups, and
+// quantifiers.
+func portablePattern(p string) bool {
+	depth := 0
+	inClass := false
+	// rangeStart is true after a literal in a class, which could be
+	// the start of a range.
+	rangeStart := false
+	for i := 0; i < len(p); i++ {
+		ch := p[i]
+		switch {
+		case ch == '\\':
+			i++
+			if i >= len(p) || !portableEscape(p[i], inClass) {
+				return false
+			}
+			rangeStart = false
+		case inClass:
+			switch {
+			case ch == ']':
+				inClass = false
+			case ch == '^' && p[i-1] == '[':
+			case ch == '-':
+				// JavaScript doesn't allow unescaped "-" except in
+				// ranges of literals.
+				if !rangeStart || i+1 >= len(p) || !classLiteral(p[i+1]) {
+					return false
+				}
+				_, n := utf8.DecodeRuneInString(p[i+1:])
+				i += n
+				rangeStart = false
+			case classLiteral(ch):
+				_, n := utf8.DecodeRuneInString(p[i:])
+				i += n - 1
+				rangeStart = true
+			default:
+				// e.g., "[:alpha:]", nested classes and the punctuation
+				// which must be escaped in the classes of JavaScript.
+				return false
+			}
+		case ch == '[':
+			inClass, rangeStart = true, false
+			if i+1 < len(p) && p[i+1] == ']' {
+				return false
+			}
+		case ch == '(':
+			if strings.HasPrefix(p[i:], "(?:") {
+				i += 2
+			} else if i+1 < len(p) && p[i+1] == '?' {
+				// Flags and named groups.
+				return false
+			}
+			depth++
+		case ch == ')':
+			if depth == 0 {
+				return false
+			}
+			depth--
+		case ch == '|':
+			if depth == 0 {
+				return false
+			}
+		case ch == '^', ch == '$', ch == ']', ch == '}':
+			return false
+		case ch == '{':
+			j := strings.IndexByte(p[i:], '}')
+			if j < 0 || !isRepeat(p[i+1:i+j]) {
+				return false
+			}
+			i += j
+		}
+	}
+	return depth == 0 && !inClass
+}
+
+// classLiteral returns true if ch, or the rune which starts with ch,
+// is a literal in the classes of both package regexp and JavaScript.
+func classLiteral(ch byte) bool {
+	return ch >= utf8.RuneSelf || ch == '_' || ch == ' ' ||
+		ch >= '0' && ch <= '9' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z'
+}
+
+// portableEscape returns true if the escape sequence of ch, i.e., \ch,
+// means the same in package regexp and in JavaScript.
+func portableEscape(ch byte, inClass bool) bool {
+	switch ch {
+	case 'd', 'D', 'w', 'W':
+		return true
+	case 'n', 't', 'r', 'f', 'v':
+		return true
+	}
+	if inClass && ch == '-' {
+		return true
+	}
+	return strings.IndexByte(`\.+*?()|[]{}^$/`, ch) >= 0
+}
+
+// isRepeat returns true if s is the content of a repetition, e.g., "2",
+// "2," or "2,5".
+func isRepeat(s string) bool {
+	lo, hi, _ := strings.Cut(s, ",")
+	if lo == "" {
+		return false
+	}
+	for _, part := range []string{lo, hi} {
+		for i := 0; i < len(part); i++ {
+			if part[i] < '0' || part[i] > '9' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (b *builder[ValueT]) addBounds(bounds constraints.Bounds[ValueT]) bool {
+	var min, max string
+	if bounds.HasMin {
+		var ok bool
+		if min, ok = b.boundValue(bounds.Min, bounds.MinExclusive, 1); !ok {
+			return false
+		}
+	}
+	if bounds.HasMax {
+		var ok bool
+		if max, ok = b.boundValue(bounds.Max, bounds.MaxExclusive, -1); !ok {
+			return false
+		}
+	}
+	if min != "" {
+		f, _ := strconv.ParseFloat(min, 64)
+		if b.attrs.Min == "" || f > b.min {
+			b.attrs.Min, b.min = min, f
+		}
+	}
+	if max != "" {
+		f, _ := strconv.ParseFloat(max, 64)
+		if b.attrs.Max == "" || f < b.max {
+			b.attrs.Max, b.max = max, f
+		}
+	}
+	return true
+}
+
+// boundValue formats a bound value. Exclusive bounds could only be
+// expressed for integers, by adjusting the value by delta, which is
+// either 1 or -1. Bounds which would overflow the type of the value,
+// e.g., greater than math.MaxInt64, couldn't be expressed.
+func (b *builder[ValueT]) boundValue(v ValueT, exclusive bool, delta int64) (string, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i := rv.Int()
+		if exclusive {
+			if (delta > 0 && i == math.MaxInt64) || (delta < 0 && i == math.MinInt64) {
+				return "", false
+			}
+			i += delta
+			if rv.OverflowInt(i) {
+				return "", false
+			}
+		}
+		return strconv.FormatInt(i, 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u := rv.Uint()
+		if exclusive {
+			if delta < 0 {
+				if u == 0 {
+					return "", false
+				}
+				u--
+			} else {
+				if u == math.MaxUint64 {
+					return "", false
+				}
+				u++
+				if rv.OverflowUint(u) {
+					return "", false
+				}
+			}
+		}
+		return strconv.FormatUint(u, 10), true
+	case reflect.Float32, reflect.Float64:
+		if exclusive {
+			return "", false
+		}
+		return strconv.FormatFloat(rv.Float(), 'g', -1, 64), true
+	}
+	return "", false
+}
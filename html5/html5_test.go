@@ -0,0 +1,153 @@
+package html5
+
+import (
+	"bytes"
+	"html/template"
+	"math"
+	"regexp"
+	"testing"
+
+	"github.com/rez-go/constraints"
+	ctesting "github.com/rez-go/constraints/internal/testing"
+	"github.com/rez-go/constraints/stdtypes"
+)
+
+var assertEq = ctesting.AssertEq
+
+func TestFromConstraintString(t *testing.T) {
+	startsWithLetter := constraints.Func("starts with a letter",
+		func(v string) bool { return v != "" })
+	c := constraints.Set(
+		stdtypes.StringRuneMinLength(6),
+		stdtypes.StringRuneMaxLength(32),
+		stdtypes.StringPattern(regexp.MustCompile(`^[A-Za-z0-9_]+$`)),
+		startsWithLetter,
+	)
+	attrs, unsupported := FromConstraint[string](c)
+	assertEq(t, Attributes{
+		Required:  true,
+		MinLength: 6,
+		MaxLength: 32,
+		Pattern:   "[A-Za-z0-9_]+",
+		Title:     c.ConstraintDescription(),
+	}, attrs)
+	assertEq(t, []constraints.ConstraintBase{startsWithLetter}, unsupported)
+}
+
+func TestFromConstraintByteLength(t *testing.T) {
+	// "日本語" is 9 bytes long but browsers count it as 3 characters.
+	maxBytes := stdtypes.StringMaxLength(6)
+	attrs, unsupported := FromConstraint[string](maxBytes)
+	assertEq(t, -1, attrs.MaxLength)
+	assertEq(t, []constraints.ConstraintBase{maxBytes}, unsupported)
+
+	minGraphemes := stdtypes.StringGraphemeMinLength(2)
+	attrs, unsupported = FromConstraint[string](minGraphemes)
+	assertEq(t, -1, attrs.MinLength)
+	assertEq(t, false, attrs.Required)
+	assertEq(t, []constraints.ConstraintBase{minGraphemes}, unsupported)
+}
+
+func TestFromConstraintPattern(t *testing.T) {
+	cases := []struct {
+		pattern string
+		attr    string
+	}{
+		{`^[A-Za-z0-9_]+$`, `[A-Za-z0-9_]+`},
+		{`^(?:a|b)\.c{2,3}$`, `(?:a|b)\.c{2,3}`},
+		{`^[^-a]$`, ""},
+		{`^[a-c-e]$`, ""},
+		{`^[日本]\d*$`, `[日本]\d*`},
+		// "(^a)|(b$)" accepts "xb", the browser doesn't.
+		{`^a|b$`, ""},
+		{`^(?i)abc$`, ""},
+		{`^abc\z$`, ""},
+		{`^[[:alpha:]]+$`, ""},
+		{`^\s+$`, ""},
+		{`^\p{L}+$`, ""},
+		{`^a{2$`, ""},
+		{`^abc`, ""},
+	}
+	for _, tc := range cases {
+		c := stdtypes.StringPattern(regexp.MustCompile(tc.pattern))
+		attrs, unsupported := FromConstraint[string](c)
+		assertEq(t, tc.attr, attrs.Pattern, tc.pattern)
+		assertEq(t, tc.attr == "", len(unsupported) == 1, tc.pattern)
+	}
+
+	// Browsers don't check the pattern of empty values.
+	attrs, unsupported := FromConstraint[string](stdtypes.StringPattern(regexp.MustCompile(`^a*$`)))
+	assertEq(t, false, attrs.Required)
+	assertEq(t, 0, len(unsupported))
+	attrs, _ = FromConstraint[string](stdtypes.StringPrefix("a"))
+	assertEq(t, true, attrs.Required)
+
+	runes := stdtypes.StringRunesAny(stdtypes.RuneRange('a', 'z'), stdtypes.RuneMatch('_'))
+	attrs, unsupported = FromConstraint[string](runes)
+	assertEq(t, "[_a-z]*", attrs.Pattern)
+	assertEq(t, 0, len(unsupported))
+	han := stdtypes.StringRunesIn(stdtypes.RuneScript("Han"))
+	attrs, unsupported = FromConstraint[string](han)
+	assertEq(t, "", attrs.Pattern)
+	assertEq(t, []constraints.ConstraintBase{han}, unsupported)
+
+	empty := constraints.Match("")
+	attrs, unsupported = FromConstraint[string](empty)
+	assertEq(t, "", attrs.Pattern)
+	assertEq(t, []constraints.ConstraintBase{empty}, unsupported)
+}
+
+func TestFromConstraintNumeric(t *testing.T) {
+	attrs, unsupported := FromConstraint[int](constraints.Set[int](
+		constraints.GreaterThan(0),
+		constraints.Max(100),
+		constraints.Range(-10, 50),
+	))
+	assertEq(t, 0, len(unsupported))
+	assertEq(t, map[string]string{
+		"min":   "1",
+		"max":   "50",
+		"step":  "1",
+		"title": "greater than 0, max 100, from -10 to 50",
+	}, attrs.Map())
+
+	attrs, unsupported = FromConstraint[float64](constraints.LessThan(1.5))
+	assertEq(t, 1, len(unsupported))
+	assertEq(t, "any", attrs.Step)
+	assertEq(t, "", attrs.Max)
+}
+
+func TestFromConstraintBoundOverflow(t *testing.T) {
+	assertBoundUnsupported[int64](t, constraints.GreaterThan(int64(math.MaxInt64)))
+	assertBoundUnsupported[int64](t, constraints.LessThan(int64(math.MinInt64)))
+	assertBoundUnsupported[uint64](t, constraints.GreaterThan(uint64(math.MaxUint64)))
+	assertBoundUnsupported[uint](t, constraints.LessThan(uint(0)))
+	assertBoundUnsupported[int8](t, constraints.GreaterThan(int8(math.MaxInt8)))
+	assertBoundUnsupported[uint8](t, constraints.GreaterThan(uint8(math.MaxUint8)))
+
+	attrs, _ := FromConstraint[int64](constraints.GreaterThan(int64(math.MaxInt64 - 1)))
+	assertEq(t, "9223372036854775807", attrs.Min)
+}
+
+func assertBoundUnsupported[ValueT any](t *testing.T, c constraints.Constraint[ValueT]) {
+	t.Helper()
+	attrs, unsupported := FromConstraint(c)
+	assertEq(t, "", attrs.Min, c.ConstraintDescription())
+	assertEq(t, "", attrs.Max, c.ConstraintDescription())
+	assertEq(t, []constraints.ConstraintBase{c}, unsupported)
+}
+
+func TestHTMLAttr(t *testing.T) {
+	attrs, _ := FromConstraint[string](constraints.Set[string](
+		stdtypes.NonEmptyString,
+		constraints.OneOf("a&b", "c"),
+	))
+	tmpl := template.Must(template.New("").Parse(`<input name="x" {{.}}>`))
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, attrs.HTMLAttr()); err != nil {
+		t.Fatal(err)
+	}
+	assertEq(t,
+		`<input name="x" required pattern="a&amp;b|c" title="non-empty, one of [a&amp;b, c]">`,
+		buf.String())
+}
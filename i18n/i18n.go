@@ -0,0 +1,340 @@
+// Package i18n renders localized descriptions of constraints.
+//
+// Descriptions are rendered from message templates looked up by
+// the ID of the constraints.Message of a constraint. A template is a text
+// with placeholders for the message arguments, e.g., "min length {min}".
+// A placeholder could have a style, e.g., "{value:q}" which renders
+// string and rune values as quoted literals, and "{constraints:or}" which
+// joins a list with the "@or" separator instead of the "@list" separator.
+// Similarly, "{fields:fields}" uses the "@fields" separator.
+//
+// Numbers are formatted with the separators of the locale. Plural and
+// select placeholders use ICU MessageFormat syntax, e.g.,
+// "{min, plural, one {# character} other {# characters}}", where the
+// plural category is selected with the CLDR rules of the locale.
+//
+// Messages without ID, e.g., of constraints created with constraints.Func
+// without the WithMessage option, are looked up by their English text.
+//
+// API status: experimental
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/rez-go/constraints"
+)
+
+// DefaultLocale is the locale of the built-in templates.
+const DefaultLocale = "en"
+
+//go:embed locales/*.json
+var builtinLocales embed.FS
+
+// A Translator renders localized descriptions of constraints.
+type Translator interface {
+	// Translate renders msg in the language of locale, e.g., "id"
+	// or "ja-JP".
+	Translate(locale string, msg constraints.Message) string
+}
+
+var (
+	_ Translator = &Catalog{}
+)
+
+// Catalog is a Translator backed by message templates of multiple
+// locales. A Catalog is safe for concurrent use.
+type Catalog struct {
+	mu        sync.RWMutex
+	templates map[string]map[string]string
+}
+
+// NewCatalog creates a Catalog which contains the built-in English
+// templates.
+func NewCatalog() *Catalog {
+	c := &Catalog{templates: map[string]map[string]string{}}
+	if err := c.LoadFS(builtinLocales, "locales"); err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Set sets the template of a message for locale. The key is the ID
+// of the message, or the English text for messages without ID.
+func (c *Catalog) Set(locale, key, template string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	locale = normalizeLocale(locale)
+	m := c.templates[locale]
+	if m == nil {
+		m = map[string]string{}
+		c.templates[locale] = m
+	}
+	m[key] = template
+}
+
+// Load loads the templates for locale from a JSON object which maps
+// keys to templates.
+func (c *Catalog) Load(locale string, r io.Reader) error {
+	var m map[string]string
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return fmt.Errorf("i18n: loading %s: %w", locale, err)
+	}
+	for k, v := range m {
+		c.Set(locale, k, v)
+	}
+	return nil
+}
+
+// LoadFS loads all the "<locale>.json" files in dir of fsys. See Load
+// for the format of the files.
+func (c *Catalog) LoadFS(fsys fs.FS, dir string) error {
+	names, err := fs.Glob(fsys, path.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		f, err := fsys.Open(name)
+		if err != nil {
+			return err
+		}
+		err = c.Load(strings.TrimSuffix(path.Base(name), ".json"), f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Describe renders the description of constraint cons in the language
+// of locale.
+func (c *Catalog) Describe(locale string, cons constraints.ConstraintBase) string {
+	return c.Translate(locale, constraints.MessageOf(cons))
+}
+
+// Translate conforms Translator interface. If there's no template for
+// msg, neither in locale nor in DefaultLocale, it returns msg.Text.
+func (c *Catalog) Translate(locale string, msg constraints.Message) string {
+	for _, l := range localeChain(locale) {
+		if tmpl, ok := c.lookup(l, msg.ID); ok {
+			return c.render(locale, tmpl, msg.Args)
+		}
+		if tmpl, ok := c.lookup(l, msg.Text); ok {
+			return c.render(locale, tmpl, msg.Args)
+		}
+	}
+	return msg.Text
+}
+
+func (c *Catalog) lookup(locale, key string) (string, bool) {
+	if key == "" {
+		return "", false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	tmpl, ok := c.templates[locale][key]
+	return tmpl, ok
+}
+
+// separator looks up a separator, e.g., "@list", in the locale chain
+// of locale.
+func (c *Catalog) separator(locale, key string) string {
+	for _, l := range localeChain(locale) {
+		if s, ok := c.lookup(l, key); ok {
+			return s
+		}
+	}
+	return ", "
+}
+
+func (c *Catalog) render(locale, tmpl string, args map[string]any) string {
+	var sb strings.Builder
+	for len(tmpl) > 0 {
+		start := strings.IndexByte(tmpl, '{')
+		if start < 0 {
+			sb.WriteString(tmpl)
+			break
+		}
+		sb.WriteString(tmpl[:start])
+		end := matchingBrace(tmpl, start)
+		if end < 0 {
+			sb.WriteString(tmpl[start:])
+			break
+		}
+		sb.WriteString(c.placeholder(locale, tmpl[start+1:end], args))
+		tmpl = tmpl[end+1:]
+	}
+	return sb.String()
+}
+
+// matchingBrace returns the index of the brace which closes the brace
+// at start, or -1 if there's none.
+func matchingBrace(s string, start int) int {
+	depth := 0
+	for i := start; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func (c *Catalog) placeholder(locale, spec string, args map[string]any) string {
+	if name, kind, options, ok := parseChoice(spec); ok {
+		v, exists := args[name]
+		if !exists {
+			return "{" + spec + "}"
+		}
+		return c.choice(locale, kind, v, options, args)
+	}
+	name, style := spec, ""
+	if i := strings.IndexByte(spec, ':'); i >= 0 {
+		name, style = spec[:i], spec[i+1:]
+	}
+	v, ok := args[strings.TrimSpace(name)]
+	if !ok {
+		return "{" + spec + "}"
+	}
+	return c.format(locale, v, strings.TrimSpace(style))
+}
+
+// parseChoice parses a plural or a select placeholder, e.g.,
+// "min, plural, one {# character} other {# characters}".
+func parseChoice(spec string) (name, kind string, options map[string]string, ok bool) {
+	parts := strings.SplitN(spec, ",", 3)
+	if len(parts) != 3 {
+		return "", "", nil, false
+	}
+	name, kind = strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+	if kind != "plural" && kind != "select" {
+		return "", "", nil, false
+	}
+	options = map[string]string{}
+	rest := parts[2]
+	for {
+		rest = strings.TrimSpace(rest)
+		if rest == "" {
+			break
+		}
+		start := strings.IndexByte(rest, '{')
+		if start <= 0 {
+			return "", "", nil, false
+		}
+		end := matchingBrace(rest, start)
+		if end < 0 {
+			return "", "", nil, false
+		}
+		options[strings.TrimSpace(rest[:start])] = rest[start+1 : end]
+		rest = rest[end+1:]
+	}
+	return name, kind, options, true
+}
+
+func (c *Catalog) choice(
+	locale, kind string,
+	v any,
+	options map[string]string,
+	args map[string]any,
+) string {
+	if kind == "select" {
+		key := fmt.Sprint(v)
+		body, ok := options[key]
+		if !ok {
+			body = options["other"]
+		}
+		return c.render(locale, body, args)
+	}
+
+	decimal, isNumber := plainDecimal(v)
+	if !isNumber {
+		return c.render(locale, options["other"], args)
+	}
+	body, ok := options["="+decimal]
+	if !ok {
+		body, ok = options[string(PluralCategoryOf(locale, decimal))]
+	}
+	if !ok {
+		body = options["other"]
+	}
+	body = strings.ReplaceAll(body, "#", FormatNumber(locale, decimal))
+	return c.render(locale, body, args)
+}
+
+func (c *Catalog) format(locale string, v any, style string) string {
+	sep := c.separator(locale, "@list")
+	switch style {
+	case "or":
+		sep = c.separator(locale, "@or")
+	case "fields":
+		sep = c.separator(locale, "@fields")
+	}
+	switch tv := v.(type) {
+	case constraints.Message:
+		return c.Translate(locale, tv)
+	case []constraints.Message:
+		parts := make([]string, 0, len(tv))
+		for _, m := range tv {
+			parts = append(parts, c.Translate(locale, m))
+		}
+		return strings.Join(parts, sep)
+	case []any:
+		parts := make([]string, 0, len(tv))
+		for _, item := range tv {
+			parts = append(parts, c.format(locale, item, style))
+		}
+		return strings.Join(parts, sep)
+	}
+	if style == "q" {
+		switch tv := v.(type) {
+		case string:
+			return strconv.Quote(tv)
+		case rune:
+			if strconv.IsPrint(tv) {
+				return fmt.Sprintf("'%c'", tv)
+			}
+			return fmt.Sprintf("0x%x", tv)
+		}
+	}
+	if decimal, ok := plainDecimal(v); ok {
+		return FormatNumber(locale, decimal)
+	}
+	return fmt.Sprint(v)
+}
+
+// localeChain returns the locales to look up for locale, from the most
+// specific one, e.g., ["pt-br", "pt", "en"] for "pt_BR".
+func localeChain(locale string) []string {
+	locale = normalizeLocale(locale)
+	var chain []string
+	for locale != "" {
+		chain = append(chain, locale)
+		i := strings.LastIndexByte(locale, '-')
+		if i < 0 {
+			break
+		}
+		locale = locale[:i]
+	}
+	if len(chain) == 0 || chain[len(chain)-1] != DefaultLocale {
+		chain = append(chain, DefaultLocale)
+	}
+	return chain
+}
+
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+}
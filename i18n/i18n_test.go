@@ -0,0 +1,96 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/rez-go/constraints"
+	ctesting "github.com/rez-go/constraints/internal/testing"
+	"github.com/rez-go/constraints/stdtypes"
+)
+
+var assertEq = ctesting.AssertEq
+
+var usernameConstraints = constraints.Set(
+	stdtypes.StringMinLength(6),
+	stdtypes.StringMaxLength(32),
+	stdtypes.StringRunesAny(
+		stdtypes.RuneRange('a', 'z'),
+		stdtypes.RuneMatch('_')),
+	constraints.Negate(stdtypes.StringSuffix("_"), "ends with anything but underscore"),
+	stdtypes.StringNoConsecutiveRune('_'),
+	constraints.OneOf("a", "b"),
+)
+
+func TestEnglishMatchesDescriptions(t *testing.T) {
+	c := NewCatalog()
+	// The English templates name the units of the lengths, which the
+	// descriptions don't.
+	assertEq(t, "min length 6 bytes, max length 32 bytes, each rune: '_' or a–z, "+
+		"ends with anything but underscore, no consecutive '_', one of [a, b]",
+		c.Describe("en-US", usernameConstraints))
+	assertEq(t, "length between 1 and 2 bytes",
+		c.Describe("en", stdtypes.StringLengthRange(1, 2)))
+	for _, class := range []*stdtypes.RuneClass{
+		stdtypes.NewRuneClass(),
+		stdtypes.NewRuneClass().Runes("_"),
+		stdtypes.NewRuneClass().Range('A', 'Z').Range('0', '9').Runes("_"),
+		stdtypes.NewRuneClass().Runes("_").Negate(),
+		stdtypes.NewRuneClass().Negate(),
+		stdtypes.RuneOneOfByString("abc_").(*stdtypes.RuneClass),
+	} {
+		rc := stdtypes.StringRunesIn(class)
+		assertEq(t, rc.ConstraintDescription(), c.Describe("en", rc))
+	}
+	for _, sc := range []stdtypes.StringConstraint{
+		stdtypes.SingleScriptString,
+		stdtypes.StringScripts("Latin", "Han"),
+		stdtypes.StringNoRunesIn(stdtypes.ControlRune.Union(stdtypes.BidiControlRune)),
+		stdtypes.NoInvisibleControlString,
+	} {
+		assertEq(t, sc.ConstraintDescription(), c.Describe("en", sc))
+	}
+}
+
+func TestLoadFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"messages/id.json": {Data: []byte(`{
+			"@or": " atau ",
+			"length.min": "panjang minimal {min}",
+			"length.max": "panjang maksimal {max}",
+			"range": "dari {min:q} sampai {max:q}",
+			"match": "sama dengan {value:q}",
+			"rune.no_consecutive": "tidak ada {rune:q} berurutan",
+			"rune.printable": "rune yang dapat dicetak",
+			"rune.class": "{items} atau {last}",
+			"string.runes_any": "{form, select, class {setiap rune: {class}} other {{constraints:or}}}",
+			"ends with anything but underscore": "tidak diakhiri garis bawah"
+		}`)},
+	}
+	c := NewCatalog()
+	assertEq(t, nil, c.LoadFS(fsys, "messages"))
+	assertEq(t,
+		"panjang minimal 6, panjang maksimal 32, "+
+			"setiap rune: '_' atau a–z, "+
+			"tidak diakhiri garis bawah, tidak ada '_' berurutan, one of [a, b]",
+		c.Describe("id_ID", usernameConstraints))
+	assertEq(t, "dari 'a' sampai 'z' atau rune yang dapat dicetak",
+		c.Describe("id_ID", stdtypes.StringRunesAny(
+			stdtypes.RuneRange('a', 'z'), stdtypes.PrintableRune)))
+}
+
+func TestLoadInvalid(t *testing.T) {
+	c := NewCatalog()
+	err := c.Load("ja", strings.NewReader(`["not an object"]`))
+	assertEq(t, true, err != nil)
+}
+
+func TestFreeFormFallback(t *testing.T) {
+	c := NewCatalog()
+	startsWithLetter := constraints.Func("starts with a letter",
+		func(v string) bool { return v != "" })
+	assertEq(t, "starts with a letter", c.Describe("ja", startsWithLetter))
+	c.Set("ja", "starts with a letter", "英字で始まる")
+	assertEq(t, "英字で始まる", c.Describe("ja", startsWithLetter))
+}
@@ -0,0 +1,132 @@
+package i18n
+
+import (
+	"strconv"
+	"strings"
+)
+
+// numberSymbols holds the symbols used to format numbers in a locale.
+type numberSymbols struct {
+	decimal string
+	group   string
+	// minGrouping is the minimum number of digits in the highest group
+	// for the grouping to be applied, e.g., Spanish doesn't group
+	// four-digit numbers.
+	minGrouping int
+}
+
+// numberSymbolsByLocale contains the number symbols of the bundled
+// locales, derived from CLDR. Locales which are not listed here use
+// the English symbols.
+var numberSymbolsByLocale = map[string]numberSymbols{
+	"en": {".", ",", 1},
+	"ja": {".", ",", 1},
+	"ko": {".", ",", 1},
+	"zh": {".", ",", 1},
+	"th": {".", ",", 1},
+	"ms": {".", ",", 1},
+	"id": {",", ".", 1},
+	"de": {",", ".", 1},
+	"nl": {",", ".", 1},
+	"it": {",", ".", 1},
+	"vi": {",", ".", 1},
+	"es": {",", ".", 2},
+	"pt": {",", ".", 1},
+	"fr": {",", " ", 1},
+	"ru": {",", " ", 1},
+	"uk": {",", " ", 1},
+	"pl": {",", " ", 2},
+	"ar": {"٫", "٬", 1},
+}
+
+func numberSymbolsOf(locale string) numberSymbols {
+	for _, l := range localeChain(locale) {
+		if s, ok := numberSymbolsByLocale[l]; ok {
+			return s
+		}
+	}
+	return numberSymbolsByLocale[DefaultLocale]
+}
+
+// FormatNumber formats a number, given as its plain decimal
+// representation, e.g., "-1234.5", with the separators of locale.
+// Other values, e.g., "+Inf" and "NaN", are returned unchanged.
+func FormatNumber(locale string, decimal string) string {
+	sym := numberSymbolsOf(locale)
+	sign, unsigned := "", decimal
+	if strings.HasPrefix(unsigned, "-") {
+		sign, unsigned = "-", unsigned[1:]
+	}
+	intPart, fracPart := unsigned, ""
+	if i := strings.IndexByte(unsigned, '.'); i >= 0 {
+		intPart, fracPart = unsigned[:i], unsigned[i+1:]
+	}
+	if !isDigits(intPart) || (fracPart != "" && !isDigits(fracPart)) {
+		return decimal
+	}
+
+	var sb strings.Builder
+	sb.WriteString(sign)
+	if len(intPart) >= 3+sym.minGrouping {
+		head := len(intPart) % 3
+		if head == 0 {
+			head = 3
+		}
+		sb.WriteString(intPart[:head])
+		for i := head; i < len(intPart); i += 3 {
+			sb.WriteString(sym.group)
+			sb.WriteString(intPart[i : i+3])
+		}
+	} else {
+		sb.WriteString(intPart)
+	}
+	if fracPart != "" {
+		sb.WriteString(sym.decimal)
+		sb.WriteString(fracPart)
+	}
+	return sb.String()
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// plainDecimal returns the plain decimal representation of v if it's
+// a number.
+func plainDecimal(v any) (string, bool) {
+	switch tv := v.(type) {
+	case int:
+		return strconv.FormatInt(int64(tv), 10), true
+	case int8:
+		return strconv.FormatInt(int64(tv), 10), true
+	case int16:
+		return strconv.FormatInt(int64(tv), 10), true
+	case int32:
+		return strconv.FormatInt(int64(tv), 10), true
+	case int64:
+		return strconv.FormatInt(tv, 10), true
+	case uint:
+		return strconv.FormatUint(uint64(tv), 10), true
+	case uint8:
+		return strconv.FormatUint(uint64(tv), 10), true
+	case uint16:
+		return strconv.FormatUint(uint64(tv), 10), true
+	case uint32:
+		return strconv.FormatUint(uint64(tv), 10), true
+	case uint64:
+		return strconv.FormatUint(tv, 10), true
+	case float32:
+		return strconv.FormatFloat(float64(tv), 'f', -1, 32), true
+	case float64:
+		return strconv.FormatFloat(tv, 'f', -1, 64), true
+	}
+	return "", false
+}
@@ -0,0 +1,42 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/rez-go/constraints"
+)
+
+func TestFormatNumber(t *testing.T) {
+	cases := []struct {
+		locale   string
+		decimal  string
+		expected string
+	}{
+		{"en", "1000000", "1,000,000"},
+		{"en", "-1234.5", "-1,234.5"},
+		{"en", "999", "999"},
+		{"id", "1000000", "1.000.000"},
+		{"id", "1234.5", "1.234,5"},
+		{"de-AT", "1234", "1.234"},
+		{"es", "1234", "1234"},
+		{"es", "12345", "12.345"},
+		{"fr", "1234.5", "1\u202f234,5"},
+		{"ja", "1000000", "1,000,000"},
+		{"en", "+Inf", "+Inf"},
+		{"en", "-Inf", "-Inf"},
+		{"en", "NaN", "NaN"},
+	}
+	for _, c := range cases {
+		assertEq(t, c.expected, FormatNumber(c.locale, c.decimal), "%s %s", c.locale, c.decimal)
+	}
+}
+
+func TestNumberArgs(t *testing.T) {
+	c := NewCatalog()
+	c.Set("id", "max", "maksimal {max}")
+	assertEq(t, "max 1,000,000", c.Describe("en", constraints.Max(1000000)))
+	assertEq(t, "maksimal 1.000.000", c.Describe("id", constraints.Max(1000000)))
+	assertEq(t, "maksimal 2,5", c.Describe("id", constraints.Max(2.5)))
+	assertEq(t, "from 'a' to 'z'", c.Describe("id", constraints.Range('a', 'z')))
+	assertEq(t, "from 1.000 to 2.000", c.Describe("id", constraints.Range(1000, 2000)))
+}
@@ -0,0 +1,165 @@
+package i18n
+
+import (
+	"strconv"
+	"strings"
+)
+
+// A PluralCategory is a CLDR plural category.
+type PluralCategory string
+
+// CLDR plural categories.
+const (
+	PluralZero  PluralCategory = "zero"
+	PluralOne   PluralCategory = "one"
+	PluralTwo   PluralCategory = "two"
+	PluralFew   PluralCategory = "few"
+	PluralMany  PluralCategory = "many"
+	PluralOther PluralCategory = "other"
+)
+
+// pluralOperands holds the CLDR plural operands of a number.
+// See https://unicode.org/reports/tr35/tr35-numbers.html#Operands
+type pluralOperands struct {
+	i uint64 // integer digits
+	v int    // number of visible fraction digits
+	f uint64 // visible fraction digits
+}
+
+// newPluralOperands derives the operands from the plain decimal
+// representation of a number, e.g., "-1.50".
+func newPluralOperands(decimal string) pluralOperands {
+	decimal = strings.TrimPrefix(decimal, "-")
+	intPart, fracPart := decimal, ""
+	if i := strings.IndexByte(decimal, '.'); i >= 0 {
+		intPart, fracPart = decimal[:i], decimal[i+1:]
+	}
+	var ops pluralOperands
+	ops.i, _ = strconv.ParseUint(intPart, 10, 64)
+	ops.v = len(fracPart)
+	if fracPart != "" {
+		ops.f, _ = strconv.ParseUint(fracPart, 10, 64)
+	}
+	return ops
+}
+
+type pluralRule func(ops pluralOperands) PluralCategory
+
+// pluralRules contains the cardinal plural rules of the bundled locales,
+// derived from CLDR. Locales which are not listed here use the English
+// rule.
+var pluralRules = map[string]pluralRule{
+	"en": pluralOneIfIntegerOne,
+	"de": pluralOneIfIntegerOne,
+	"nl": pluralOneIfIntegerOne,
+	"it": pluralWithMillions(pluralOneIfIntegerOne),
+	"es": pluralWithMillions(pluralOneIfIntegerOne),
+	"pt": pluralWithMillions(pluralZeroOrOne),
+	"fr": pluralWithMillions(pluralZeroOrOne),
+	"id": pluralOtherOnly,
+	"ms": pluralOtherOnly,
+	"ja": pluralOtherOnly,
+	"ko": pluralOtherOnly,
+	"zh": pluralOtherOnly,
+	"th": pluralOtherOnly,
+	"vi": pluralOtherOnly,
+	"ru": pluralEastSlavic,
+	"uk": pluralEastSlavic,
+	"pl": pluralPolish,
+	"ar": pluralArabic,
+}
+
+// PluralCategoryOf returns the cardinal plural category of number n,
+// given as its plain decimal representation, e.g., "1" or "1.50", in
+// the language of locale.
+func PluralCategoryOf(locale string, n string) PluralCategory {
+	return pluralRuleOf(locale)(newPluralOperands(n))
+}
+
+func pluralRuleOf(locale string) pluralRule {
+	for _, l := range localeChain(locale) {
+		if r, ok := pluralRules[l]; ok {
+			return r
+		}
+	}
+	return pluralOneIfIntegerOne
+}
+
+func pluralOtherOnly(pluralOperands) PluralCategory { return PluralOther }
+
+func pluralOneIfIntegerOne(ops pluralOperands) PluralCategory {
+	if ops.i == 1 && ops.v == 0 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+func pluralZeroOrOne(ops pluralOperands) PluralCategory {
+	if ops.i == 0 || ops.i == 1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// pluralWithMillions adds the many category of the Romance languages,
+// for the multiples of a million, e.g., "1 million de …" in French, to
+// rule.
+func pluralWithMillions(rule pluralRule) pluralRule {
+	return func(ops pluralOperands) PluralCategory {
+		if c := rule(ops); c != PluralOther {
+			return c
+		}
+		if ops.v == 0 && ops.i != 0 && ops.i%1000000 == 0 {
+			return PluralMany
+		}
+		return PluralOther
+	}
+}
+
+func pluralEastSlavic(ops pluralOperands) PluralCategory {
+	if ops.v != 0 {
+		return PluralOther
+	}
+	mod10, mod100 := ops.i%10, ops.i%100
+	switch {
+	case mod10 == 1 && mod100 != 11:
+		return PluralOne
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return PluralFew
+	}
+	return PluralMany
+}
+
+func pluralPolish(ops pluralOperands) PluralCategory {
+	if ops.v != 0 {
+		return PluralOther
+	}
+	mod10, mod100 := ops.i%10, ops.i%100
+	switch {
+	case ops.i == 1:
+		return PluralOne
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return PluralFew
+	}
+	return PluralMany
+}
+
+func pluralArabic(ops pluralOperands) PluralCategory {
+	if ops.v != 0 {
+		return PluralOther
+	}
+	mod100 := ops.i % 100
+	switch {
+	case ops.i == 0:
+		return PluralZero
+	case ops.i == 1:
+		return PluralOne
+	case ops.i == 2:
+		return PluralTwo
+	case mod100 >= 3 && mod100 <= 10:
+		return PluralFew
+	case mod100 >= 11:
+		return PluralMany
+	}
+	return PluralOther
+}
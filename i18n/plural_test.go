@@ -0,0 +1,87 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/rez-go/constraints"
+	"github.com/rez-go/constraints/stdtypes"
+)
+
+func TestPluralCategoryOf(t *testing.T) {
+	cases := []struct {
+		locale   string
+		n        string
+		category PluralCategory
+	}{
+		{"en", "1", PluralOne},
+		{"en", "1.0", PluralOther},
+		{"en", "6", PluralOther},
+		{"fr", "0", PluralOne},
+		{"fr", "1.5", PluralOne},
+		{"fr", "1000000", PluralMany},
+		{"fr", "1000001", PluralOther},
+		{"es", "2000000", PluralMany},
+		{"es", "1000000.5", PluralOther},
+		{"it", "1000000", PluralMany},
+		{"pt", "1000000", PluralMany},
+		{"en", "1000000", PluralOther},
+		{"id", "1", PluralOther},
+		{"ja-JP", "1", PluralOther},
+		{"ru", "1", PluralOne},
+		{"ru", "11", PluralMany},
+		{"ru", "22", PluralFew},
+		{"ru", "25", PluralMany},
+		{"ru", "1.5", PluralOther},
+		{"pl", "1", PluralOne},
+		{"pl", "21", PluralMany},
+		{"pl", "23", PluralFew},
+		{"ar", "0", PluralZero},
+		{"ar", "2", PluralTwo},
+		{"ar", "103", PluralFew},
+		{"ar", "111", PluralMany},
+		{"ar", "100", PluralOther},
+		{"xx", "1", PluralOne},
+	}
+	for _, c := range cases {
+		assertEq(t, c.category, PluralCategoryOf(c.locale, c.n), "%s %s", c.locale, c.n)
+	}
+}
+
+func TestPluralMessages(t *testing.T) {
+	c := NewCatalog()
+	c.Set("en", "length.min",
+		"min length {min, plural, =0 {zero} one {# character} other {# characters}}")
+	c.Set("ru", "length.min",
+		"не менее {min, plural, one {# символа} other {# символов}}")
+	assertEq(t, "min length 1 character", c.Describe("en", stdtypes.StringMinLength(1)))
+	assertEq(t, "min length 6 characters", c.Describe("en", stdtypes.StringMinLength(6)))
+	assertEq(t, "min length zero", c.Describe("en", stdtypes.StringMinLength(0)))
+	assertEq(t, "min length 1,000 characters", c.Describe("en", stdtypes.StringMinLength(1000)))
+	assertEq(t, "не менее 21 символа", c.Describe("ru", stdtypes.StringMinLength(21)))
+	assertEq(t, "не менее 25 символов", c.Describe("ru", stdtypes.StringMinLength(25)))
+}
+
+func TestBundledPluralMessages(t *testing.T) {
+	c := NewCatalog()
+	assertEq(t, "min length 1 character", c.Describe("en", stdtypes.StringRuneMinLength(1)))
+	assertEq(t, "max length 6 characters", c.Describe("en", stdtypes.StringRuneMaxLength(6)))
+	assertEq(t, "length 1 character", c.Describe("en", stdtypes.StringGraphemeLength(1)))
+	assertEq(t, "length between 1 and 1,000 characters",
+		c.Describe("en", stdtypes.StringRuneLengthRange(1, 1000)))
+	assertEq(t, "max length 1 byte", c.Describe("en", stdtypes.StringMaxLength(1)))
+	assertEq(t, "min length 2 items",
+		c.Describe("en", stdtypes.SliceMinLength[[]int](2)))
+	assertEq(t, "max length 1 item",
+		c.Describe("en", stdtypes.MapMaxLength[map[string]int](1)))
+}
+
+func TestSelectMessages(t *testing.T) {
+	c := NewCatalog()
+	c.Set("en", "checked", "{state, select, on {enabled} other {disabled}}")
+	on := constraints.Func("on", func(bool) bool { return true },
+		constraints.WithMessage("checked", map[string]any{"state": "on"}))
+	off := constraints.Func("off", func(bool) bool { return true },
+		constraints.WithMessage("checked", map[string]any{"state": "off"}))
+	assertEq(t, "enabled", c.Describe("en", on))
+	assertEq(t, "disabled", c.Describe("en", off))
+}
@@ -0,0 +1,158 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/rez-go/constraints"
+	"github.com/rez-go/constraints/stdtypes"
+)
+
+func marshal(t *testing.T, s Schema) string {
+	t.Helper()
+	b, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestConvertNumeric(t *testing.T) {
+	assertEq(t, `{"minimum":5,"type":"integer"}`,
+		marshal(t, Convert[int](constraints.Min(5))))
+	assertEq(t, `{"exclusiveMaximum":1.5,"type":"number"}`,
+		marshal(t, Convert[float64](constraints.LessThan(1.5))))
+	assertEq(t, `{"maximum":10,"minimum":0,"type":"integer"}`,
+		marshal(t, Convert[int](constraints.Range(0, 10))))
+	assertEq(t, `{"description":"even","type":"integer"}`,
+		marshal(t, Convert[int](stdtypes.IntEven)))
+}
+
+func TestConvertString(t *testing.T) {
+	c := constraints.Set[string](
+		stdtypes.StringRuneMinLength(6),
+		stdtypes.StringRuneMaxLength(32),
+		constraints.Negate[string](stdtypes.StringSuffix("_"), ""),
+		constraints.Any[string](
+			constraints.OneOf("admin", "root"),
+			constraints.Match("")),
+	)
+	assertEq(t,
+		`{"allOf":[{"minLength":6},{"maxLength":32},{"not":{"pattern":"_$"}},`+
+			`{"anyOf":[{"enum":["admin","root"]},{"const":""}]}],"type":"string"}`,
+		marshal(t, Convert[string](c)))
+}
+
+func TestConvertRuneClass(t *testing.T) {
+	c := stdtypes.StringRunesAny(stdtypes.RuneRange('a', 'z'), stdtypes.RuneMatch('_'))
+	assertEq(t, `{"pattern":"^[_a-z]*$","type":"string"}`,
+		marshal(t, Convert[string](c)))
+	assertEq(t, `{"description":"each rune: Han script","type":"string"}`,
+		marshal(t, Convert[string](stdtypes.StringRunesIn(stdtypes.RuneScript("Han")))))
+}
+
+func TestConvertEmptySet(t *testing.T) {
+	assertEq(t, `{"type":"string"}`, marshal(t, Convert[string](constraints.Set[string]())))
+}
+
+type testEmailConstraint struct {
+	constraints.Constraint[string]
+	schema Schema
+}
+
+func (c testEmailConstraint) JSONSchema() Schema { return c.schema }
+
+func TestConvertSchemaProvider(t *testing.T) {
+	c := testEmailConstraint{
+		Constraint: stdtypes.NonEmptyString,
+		schema:     Schema{"format": "email"},
+	}
+	assertEq(t, `{"format":"email","type":"string"}`, marshal(t, Convert[string](c)))
+	assertEq(t, `{"format":"email"}`, marshal(t, c.schema))
+}
+
+func TestConvertConditional(t *testing.T) {
+	c := constraints.When[string](constraints.Match("ID"),
+		stdtypes.StringRuneMinLength(5), nil)
+	assertEq(t, `{"if":{"const":"ID"},"then":{"minLength":5},"type":"string"}`,
+		marshal(t, Convert[string](c)))
+}
+
+func TestConvertByteLength(t *testing.T) {
+	// JSON Schema counts code points, not bytes.
+	assertEq(t, `{"description":"min length 6","type":"string"}`,
+		marshal(t, Convert[string](stdtypes.StringMinLength(6))))
+	assertEq(t, `{"description":"max grapheme length 6","type":"string"}`,
+		marshal(t, Convert[string](stdtypes.StringGraphemeMaxLength(6))))
+}
+
+func TestConvertCollections(t *testing.T) {
+	tags := constraints.Set[[]string](
+		stdtypes.SliceLengthRange[[]string](1, 5),
+		stdtypes.UniqueItems[[]string](),
+	)
+	assertEq(t, `{"allOf":[{"maxItems":5,"minItems":1},{"uniqueItems":true}],"type":"array"}`,
+		marshal(t, Convert[[]string](tags)))
+	assertEq(t, `{"minProperties":1,"type":"object"}`,
+		marshal(t, Convert[map[string]int](stdtypes.MapMinLength[map[string]int](1))))
+	assertEq(t, `{"description":"max length 4"}`,
+		marshal(t, Convert[[]byte](stdtypes.BytesMaxLength(4))))
+}
+
+func TestConvertNonNumericBounds(t *testing.T) {
+	assertEq(t, `{"description":"min b","type":"string"}`,
+		marshal(t, Convert[string](constraints.Min("b"))))
+}
+
+func TestDocument(t *testing.T) {
+	assertEq(t,
+		`{"$schema":"https://json-schema.org/draft/2020-12/schema","not":{"enum":[1,2]},"type":"integer"}`,
+		marshal(t, Document[int](Draft202012, constraints.NoneOf(1, 2))))
+}
+
+func TestField(t *testing.T) {
+	assertEq(t, `{"username":{"minLength":6,"type":"string"}}`,
+		marshal(t, Field[string]("username", stdtypes.StringRuneMinLength(6))))
+}
+
+// TestFieldREADME converts the username constraints of the README.
+func TestFieldREADME(t *testing.T) {
+	usernameConstraints := constraints.Set[string](
+		stdtypes.StringRuneMinLength(6),
+		stdtypes.StringRuneMaxLength(32),
+		constraints.Func(`allowed characters are A to Z, 0 to 9 and underscore`,
+			regexp.MustCompile(`^[A-Za-z0-9_]+$`).MatchString),
+		constraints.Func(`starts with a letter`,
+			func(v string) bool { return v != "" }),
+		constraints.Negate[string](stdtypes.StringSuffix("_"),
+			`ends with anything but underscore`),
+		stdtypes.StringNoConsecutiveRune('_'),
+	)
+	assertEq(t,
+		`{"username":{"allOf":[{"minLength":6},{"maxLength":32},`+
+			`{"description":"allowed characters are A to Z, 0 to 9 and underscore"},`+
+			`{"description":"starts with a letter"},{"not":{"pattern":"_$"}},`+
+			`{"description":"no consecutive '_'"}],"type":"string"}}`,
+		marshal(t, Field[string]("username", usernameConstraints)))
+
+	// With byte lengths, as in earlier versions of the README, the
+	// lengths are only described.
+	assertEq(t, `{"username":{"allOf":[{"description":"min length 6"}],"type":"string"}}`,
+		marshal(t, Field[string]("username",
+			constraints.Set[string](stdtypes.StringMinLength(6)))))
+}
+
+func TestFlatten(t *testing.T) {
+	c := constraints.Set[string](
+		stdtypes.StringRuneMinLength(6),
+		stdtypes.StringRuneMaxLength(32),
+		constraints.Set[string](constraints.OneOf("a", "b")),
+		constraints.Func("odd", func(string) bool { return true }),
+		constraints.Func("even", func(string) bool { return true }),
+	)
+	assertEq(t,
+		`{"allOf":[{"description":"even"}],"description":"odd","enum":["a","b"],`+
+			`"maxLength":32,"minLength":6,"type":"string"}`,
+		marshal(t, Flatten(Convert[string](c))))
+}
@@ -0,0 +1,307 @@
+// Package jsonschema converts constraints into JSON Schema keywords.
+//
+// The generated keywords are compatible with both draft-07 and
+// draft 2020-12 of the JSON Schema specification.
+//
+// API status: experimental
+package jsonschema
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/rez-go/constraints"
+	"github.com/rez-go/constraints/stdtypes"
+)
+
+// A Schema is a JSON Schema object.
+type Schema map[string]any
+
+// A Draft identifies a version of the JSON Schema specification by
+// its meta-schema URI.
+type Draft string
+
+// Supported drafts.
+const (
+	Draft07     Draft = "http://json-schema.org/draft-07/schema#"
+	Draft202012 Draft = "https://json-schema.org/draft/2020-12/schema"
+)
+
+// SchemaProvider could be implemented by constraints which know how to
+// describe themselves in JSON Schema. It takes precedence over the
+// built-in conversion.
+type SchemaProvider interface {
+	JSONSchema() Schema
+}
+
+// Convert creates a Schema from constraint c. The "type" keyword is
+// derived from ValueT when it's a string, a boolean, a numeric type,
+// a slice or a map.
+//
+// Constraints which have no JSON Schema counterpart, e.g., those created
+// with constraints.Func, are converted into schemas which contain only
+// the "description" keyword. Note that the string lengths of JSON Schema
+// are in code points, so only the rune lengths, e.g.,
+// stdtypes.StringRuneMinLength, are converted into minLength and
+// maxLength.
+func Convert[ValueT any](c constraints.Constraint[ValueT]) Schema {
+	s := convert(c)
+	if t := typeName[ValueT](); t != "" {
+		s["type"] = t
+	}
+	return s
+}
+
+// Document creates a root Schema, i.e., with "$schema" keyword, from
+// constraint c.
+func Document[ValueT any](draft Draft, c constraints.Constraint[ValueT]) Schema {
+	s := Convert(c)
+	s["$schema"] = string(draft)
+	return s
+}
+
+// Field creates a Schema of an object property named name. The result
+// is suitable to be merged into the "properties" of an object schema.
+func Field[ValueT any](name string, c constraints.Constraint[ValueT]) Schema {
+	return Schema{name: Convert(c)}
+}
+
+func convert[ValueT any](c constraints.Constraint[ValueT]) Schema {
+	if c == nil {
+		return Schema{}
+	}
+	if sp, ok := c.(SchemaProvider); ok {
+		// The keywords, e.g., "type", are added to a copy so that
+		// the schema of the provider is not modified.
+		s := Schema{}
+		for k, v := range sp.JSONSchema() {
+			s[k] = v
+		}
+		return s
+	}
+
+	switch constraints.KindOf(c) {
+	case constraints.KindSet:
+		if children, ok := childSchemas(c); ok {
+			// allOf must be non-empty. An empty set allows anything.
+			if len(children) == 0 {
+				return Schema{}
+			}
+			return Schema{"allOf": children}
+		}
+	case constraints.KindAny:
+		if children, ok := childSchemas(c); ok {
+			return Schema{"anyOf": children}
+		}
+	case constraints.KindNegate:
+		if children, ok := childSchemas(c); ok && len(children) == 1 {
+			return Schema{"not": children[0]}
+		}
+	case constraints.KindWhen:
+		if cc, ok := c.(constraints.ConditionalConstraint[ValueT]); ok {
+			s := Schema{"if": convert(cc.Condition())}
+			if then := cc.Then(); then != nil {
+				s["then"] = convert(then)
+			}
+			if els := cc.Else(); els != nil {
+				s["else"] = convert(els)
+			}
+			return s
+		}
+	case constraints.KindMatch:
+		if oc, ok := c.(constraints.OperandConstraint[ValueT]); ok {
+			return Schema{"const": oc.Operand()}
+		}
+	case constraints.KindOneOf:
+		if oc, ok := c.(constraints.OptionsConstraint[ValueT]); ok {
+			return Schema{"enum": anySlice(oc.Options())}
+		}
+	case constraints.KindNoneOf:
+		if oc, ok := c.(constraints.OptionsConstraint[ValueT]); ok {
+			return Schema{"not": Schema{"enum": anySlice(oc.Options())}}
+		}
+	case constraints.KindMin, constraints.KindMax,
+		constraints.KindLessThan, constraints.KindLessThanOrEqualTo,
+		constraints.KindGreaterThan, constraints.KindGreaterThanOrEqualTo,
+		constraints.KindRange:
+		if bc, ok := c.(constraints.BoundedConstraint[ValueT]); ok && isNumeric[ValueT]() {
+			return boundsSchema(bc.Bounds())
+		}
+	case stdtypes.KindLength, stdtypes.KindLengthMin,
+		stdtypes.KindLengthMax, stdtypes.KindLengthRange,
+		stdtypes.KindRuneLength, stdtypes.KindRuneLengthMin,
+		stdtypes.KindRuneLengthMax, stdtypes.KindRuneLengthRange:
+		// The lengths of strings in JSON Schema are the numbers of
+		// code points. The lengths in bytes are only described.
+		if lc, ok := c.(stdtypes.LengthUnitConstraint); ok {
+			switch {
+			case typeName[ValueT]() == "string" && lc.LengthUnit() == stdtypes.LengthUnitRunes:
+				return lengthSchema(lc, "minLength", "maxLength")
+			case typeName[ValueT]() == "array" && lc.LengthUnit() == stdtypes.LengthUnitElements:
+				return lengthSchema(lc, "minItems", "maxItems")
+			case typeName[ValueT]() == "object" && lc.LengthUnit() == stdtypes.LengthUnitElements:
+				return lengthSchema(lc, "minProperties", "maxProperties")
+			}
+		}
+	case stdtypes.KindSliceUnique:
+		return Schema{"uniqueItems": true}
+	case stdtypes.KindStringPrefix:
+		if oc, ok := c.(constraints.OperandConstraint[string]); ok {
+			return Schema{"pattern": "^" + regexp.QuoteMeta(oc.Operand())}
+		}
+	case stdtypes.KindStringSuffix:
+		if oc, ok := c.(constraints.OperandConstraint[string]); ok {
+			return Schema{"pattern": regexp.QuoteMeta(oc.Operand()) + "$"}
+		}
+	case stdtypes.KindStringPattern:
+		if oc, ok := c.(constraints.OperandConstraint[string]); ok {
+			return Schema{"pattern": oc.Operand()}
+		}
+	case stdtypes.KindStringRunesIn:
+		// The classes with non-ASCII runes or Unicode tables are only
+		// described, as their regular expressions use the syntax of
+		// package regexp, e.g., \x{FF} and \p{Han}.
+		if rc, ok := c.(stdtypes.RuneClassConstraint); ok {
+			re := rc.RuneClass().Regexp()
+			if !strings.Contains(re, `\x{`) && !strings.Contains(re, `\p{`) {
+				return Schema{"pattern": "^" + re + "*$"}
+			}
+		}
+	}
+
+	return Schema{"description": c.ConstraintDescription()}
+}
+
+func childSchemas[ValueT any](c constraints.Constraint[ValueT]) ([]Schema, bool) {
+	cc, ok := c.(constraints.CompositeConstraint[ValueT])
+	if !ok {
+		return nil, false
+	}
+	children := cc.Children()
+	schemas := make([]Schema, 0, len(children))
+	for _, child := range children {
+		schemas = append(schemas, convert(child))
+	}
+	return schemas, true
+}
+
+func boundsSchema[ValueT any](b constraints.Bounds[ValueT]) Schema {
+	s := Schema{}
+	if b.HasMin {
+		if b.MinExclusive {
+			s["exclusiveMinimum"] = b.Min
+		} else {
+			s["minimum"] = b.Min
+		}
+	}
+	if b.HasMax {
+		if b.MaxExclusive {
+			s["exclusiveMaximum"] = b.Max
+		} else {
+			s["maximum"] = b.Max
+		}
+	}
+	return s
+}
+
+func lengthSchema(lc stdtypes.LengthBoundedConstraint, minKeyword, maxKeyword string) Schema {
+	s := Schema{}
+	min, max := lc.LengthBounds()
+	if min >= 0 {
+		s[minKeyword] = min
+	}
+	if max >= 0 {
+		s[maxKeyword] = max
+	}
+	return s
+}
+
+func anySlice[ValueT any](values []ValueT) []any {
+	result := make([]any, 0, len(values))
+	for _, v := range values {
+		result = append(result, v)
+	}
+	return result
+}
+
+func valueKind[ValueT any]() reflect.Kind {
+	return reflect.TypeOf((*ValueT)(nil)).Elem().Kind()
+}
+
+func isNumeric[ValueT any]() bool {
+	switch valueKind[ValueT]() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr, reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func typeName[ValueT any]() string {
+	switch valueKind[ValueT]() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		// Byte slices are usually encoded as strings.
+		if reflect.TypeOf((*ValueT)(nil)).Elem().Elem().Kind() != reflect.Uint8 {
+			return "array"
+		}
+	case reflect.Map:
+		return "object"
+	}
+	return ""
+}
+
+// Flatten merges the subschemas of the "allOf" keyword of s into s
+// itself where the keywords don't conflict. Subschemas which couldn't be
+// merged are kept in "allOf". The result is equivalent to s but it's
+// usually easier to read for humans and tools.
+func Flatten(s Schema) Schema {
+	result := make(Schema, len(s))
+	for k, v := range s {
+		if k != "allOf" {
+			result[k] = v
+		}
+	}
+	all, ok := s["allOf"].([]Schema)
+	if !ok {
+		if v, exists := s["allOf"]; exists {
+			result["allOf"] = v
+		}
+		return result
+	}
+	var rest []Schema
+	for _, sub := range all {
+		sub = Flatten(sub)
+		if !canMerge(result, sub) {
+			rest = append(rest, sub)
+			continue
+		}
+		for k, v := range sub {
+			result[k] = v
+		}
+	}
+	if len(rest) > 0 {
+		result["allOf"] = rest
+	}
+	return result
+}
+
+func canMerge(dst, src Schema) bool {
+	for k := range src {
+		if _, exists := dst[k]; exists {
+			return false
+		}
+	}
+	return true
+}
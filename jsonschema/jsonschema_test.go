@@ -0,0 +1,7 @@
+package jsonschema
+
+import (
+	"github.com/rez-go/constraints/internal/testing"
+)
+
+var assertEq = testing.AssertEq
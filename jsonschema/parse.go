@@ -0,0 +1,532 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rez-go/constraints"
+	"github.com/rez-go/constraints/stdtypes"
+)
+
+// An UnsupportedKeywordsError is returned when a schema contains keywords
+// which couldn't be converted into constraints.
+type UnsupportedKeywordsError struct {
+	// Keywords contains the JSON Pointers of the unsupported keywords,
+	// e.g., "/allOf/0/oneOf".
+	Keywords []string
+}
+
+func (e *UnsupportedKeywordsError) Error() string {
+	return "jsonschema: unsupported keywords: " + strings.Join(e.Keywords, ", ")
+}
+
+// annotationKeywords are keywords which don't affect validation. They
+// are ignored by the parser.
+var annotationKeywords = map[string]bool{
+	"$schema":     true,
+	"$id":         true,
+	"$comment":    true,
+	"title":       true,
+	"description": true,
+	"default":     true,
+	"examples":    true,
+	"deprecated":  true,
+	"readOnly":    true,
+	"writeOnly":   true,
+}
+
+// Parse creates a constraint tree from a JSON-encoded schema.
+//
+// Supported keywords are type, const, enum, minimum, maximum,
+// exclusiveMinimum, exclusiveMaximum, minLength, maxLength, pattern,
+// allOf, anyOf and not. The lengths of minLength and maxLength are
+// the numbers of code points, i.e., runes, as in the specification.
+// Annotations, e.g., title and description, are
+// ignored. If the schema contains any other keyword, Parse returns
+// an *UnsupportedKeywordsError.
+//
+// API status: experimental
+func Parse(data []byte) (constraints.Constraint[any], error) {
+	var schema any
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("jsonschema: %w", err)
+	}
+	return parseRoot(schema)
+}
+
+// FromMap creates a constraint tree from a decoded schema. See Parse
+// for the supported keywords.
+func FromMap(schema map[string]any) (constraints.Constraint[any], error) {
+	return parseRoot(schema)
+}
+
+func parseRoot(schema any) (constraints.Constraint[any], error) {
+	p := &parser{}
+	c, err := p.parse(schema, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(p.unsupported) > 0 {
+		sort.Strings(p.unsupported)
+		return nil, &UnsupportedKeywordsError{Keywords: p.unsupported}
+	}
+	return c, nil
+}
+
+type parser struct {
+	unsupported []string
+}
+
+func (p *parser) parse(schema any, ptr string) (constraints.Constraint[any], error) {
+	var obj map[string]any
+	switch ts := schema.(type) {
+	case bool:
+		if ts {
+			return constraints.Set[any](), nil
+		}
+		return constraints.Func("nothing", func(any) bool { return false }), nil
+	case map[string]any:
+		obj = ts
+	case Schema:
+		obj = ts
+	default:
+		return nil, fmt.Errorf("jsonschema: %s: schema must be an object or a boolean", pointer(ptr))
+	}
+
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var list []constraints.Constraint[any]
+	if hasAnyKey(obj, numericBoundsKeywords) {
+		c, err := parseNumericBounds(obj, ptr)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, c)
+	}
+	if hasAnyKey(obj, lengthBoundsKeywords) {
+		c, err := parseLengthBounds(obj, ptr)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, c)
+	}
+	for _, k := range keys {
+		if annotationKeywords[k] || inKeywords(k, numericBoundsKeywords) ||
+			inKeywords(k, lengthBoundsKeywords) {
+			continue
+		}
+		kptr := ptr + "/" + escapePointer(k)
+		v := obj[k]
+		var c constraints.Constraint[any]
+		var err error
+		switch k {
+		case "type":
+			c, err = parseType(v, kptr)
+		case "const":
+			c = jsonEqual(v)
+		case "enum":
+			c, err = parseEnum(v, kptr)
+		case "pattern":
+			c, err = parsePattern(v, kptr)
+		case "allOf", "anyOf":
+			var children []constraints.Constraint[any]
+			children, err = p.parseList(v, kptr)
+			if err == nil {
+				if k == "allOf" {
+					c = constraints.Set(children...)
+				} else {
+					c = constraints.Any(children...)
+				}
+			}
+		case "not":
+			var negated constraints.Constraint[any]
+			negated, err = p.parse(v, kptr)
+			if err == nil {
+				c = constraints.Negate(negated, "")
+			}
+		case "if":
+			c, err = p.parseConditional(obj, ptr)
+		case "then", "else":
+			// Handled with "if". Without "if", they have no effect.
+			continue
+		default:
+			p.unsupported = append(p.unsupported, pointer(kptr))
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, c)
+	}
+
+	if len(list) == 1 {
+		return list[0], nil
+	}
+	return constraints.Set(list...), nil
+}
+
+var (
+	numericBoundsKeywords = []string{"minimum", "exclusiveMinimum", "maximum", "exclusiveMaximum"}
+	lengthBoundsKeywords  = []string{"minLength", "maxLength"}
+)
+
+func inKeywords(k string, keywords []string) bool {
+	for _, kw := range keywords {
+		if k == kw {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAnyKey(obj map[string]any, keywords []string) bool {
+	for _, kw := range keywords {
+		if _, ok := obj[kw]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// parseConditional parses the "if", "then" and "else" keywords of
+// the schema obj located at ptr.
+func (p *parser) parseConditional(obj map[string]any, ptr string) (constraints.Constraint[any], error) {
+	cond, err := p.parse(obj["if"], ptr+"/if")
+	if err != nil {
+		return nil, err
+	}
+	var then, els constraints.Constraint[any]
+	if v, ok := obj["then"]; ok {
+		if then, err = p.parse(v, ptr+"/then"); err != nil {
+			return nil, err
+		}
+	}
+	if v, ok := obj["else"]; ok {
+		if els, err = p.parse(v, ptr+"/else"); err != nil {
+			return nil, err
+		}
+	}
+	return constraints.When(cond, then, els), nil
+}
+
+func (p *parser) parseList(v any, ptr string) ([]constraints.Constraint[any], error) {
+	items, ok := v.([]any)
+	if !ok || len(items) == 0 {
+		return nil, fmt.Errorf("jsonschema: %s: must be a non-empty array", pointer(ptr))
+	}
+	list := make([]constraints.Constraint[any], 0, len(items))
+	for i, item := range items {
+		c, err := p.parse(item, ptr+"/"+strconv.Itoa(i))
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, c)
+	}
+	return list, nil
+}
+
+func parseType(v any, ptr string) (constraints.Constraint[any], error) {
+	var names []string
+	switch tv := v.(type) {
+	case string:
+		names = []string{tv}
+	case []any:
+		for _, item := range tv {
+			name, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("jsonschema: %s: must be a string or an array of strings", pointer(ptr))
+			}
+			names = append(names, name)
+		}
+	default:
+		return nil, fmt.Errorf("jsonschema: %s: must be a string or an array of strings", pointer(ptr))
+	}
+	for _, name := range names {
+		if _, ok := typeCheckers[name]; !ok {
+			return nil, fmt.Errorf("jsonschema: %s: unknown type %q", pointer(ptr), name)
+		}
+	}
+	c := constraints.Func(
+		"of type "+strings.Join(names, " or "),
+		func(v any) bool {
+			for _, name := range names {
+				if typeCheckers[name](v) {
+					return true
+				}
+			}
+			return false
+		})
+	return &adapted[any]{c: c, convert: identity, schema: Schema{"type": v}}, nil
+}
+
+var typeCheckers = map[string]func(v any) bool{
+	"null":    func(v any) bool { return v == nil },
+	"boolean": func(v any) bool { _, ok := v.(bool); return ok },
+	"string":  func(v any) bool { _, ok := v.(string); return ok },
+	"array":   func(v any) bool { _, ok := v.([]any); return ok },
+	"object": func(v any) bool {
+		switch v.(type) {
+		case map[string]any, Schema:
+			return true
+		}
+		return false
+	},
+	"number": func(v any) bool { _, ok := toFloat64(v); return ok },
+	"integer": func(v any) bool {
+		f, ok := toFloat64(v)
+		return ok && f == math.Trunc(f)
+	},
+}
+
+func parseEnum(v any, ptr string) (constraints.Constraint[any], error) {
+	items, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("jsonschema: %s: must be an array", pointer(ptr))
+	}
+	return jsonOneOf(items), nil
+}
+
+func parseNumericBounds(obj map[string]any, ptr string) (constraints.Constraint[any], error) {
+	var bounds constraints.Bounds[float64]
+	schema := Schema{}
+	for _, k := range numericBoundsKeywords {
+		v, ok := obj[k]
+		if !ok {
+			continue
+		}
+		f, ok := toFloat64(v)
+		if !ok {
+			return nil, fmt.Errorf("jsonschema: %s: must be a number", ptr+"/"+k)
+		}
+		schema[k] = v
+		switch k {
+		case "minimum":
+			if !bounds.HasMin || f > bounds.Min {
+				bounds.Min, bounds.HasMin, bounds.MinExclusive = f, true, false
+			}
+		case "exclusiveMinimum":
+			if !bounds.HasMin || f >= bounds.Min {
+				bounds.Min, bounds.HasMin, bounds.MinExclusive = f, true, true
+			}
+		case "maximum":
+			if !bounds.HasMax || f < bounds.Max {
+				bounds.Max, bounds.HasMax, bounds.MaxExclusive = f, true, false
+			}
+		case "exclusiveMaximum":
+			if !bounds.HasMax || f <= bounds.Max {
+				bounds.Max, bounds.HasMax, bounds.MaxExclusive = f, true, true
+			}
+		}
+	}
+
+	var c constraints.Constraint[float64]
+	switch {
+	case bounds.HasMin && bounds.HasMax && !bounds.MinExclusive && !bounds.MaxExclusive:
+		c = constraints.Range(bounds.Min, bounds.Max)
+	case bounds.HasMin && bounds.HasMax:
+		c = constraints.Set(boundConstraints(bounds)...)
+	default:
+		c = boundConstraints(bounds)[0]
+	}
+	return &adapted[float64]{c: c, convert: toFloat64, schema: schema}, nil
+}
+
+func boundConstraints(b constraints.Bounds[float64]) []constraints.Constraint[float64] {
+	var list []constraints.Constraint[float64]
+	if b.HasMin {
+		if b.MinExclusive {
+			list = append(list, constraints.GreaterThan(b.Min))
+		} else {
+			list = append(list, constraints.Min(b.Min))
+		}
+	}
+	if b.HasMax {
+		if b.MaxExclusive {
+			list = append(list, constraints.LessThan(b.Max))
+		} else {
+			list = append(list, constraints.Max(b.Max))
+		}
+	}
+	return list
+}
+
+func parseLengthBounds(obj map[string]any, ptr string) (constraints.Constraint[any], error) {
+	min, max := -1, -1
+	schema := Schema{}
+	for _, k := range lengthBoundsKeywords {
+		v, ok := obj[k]
+		if !ok {
+			continue
+		}
+		f, ok := toFloat64(v)
+		if !ok || f < 0 || f != math.Trunc(f) {
+			return nil, fmt.Errorf("jsonschema: %s: must be a non-negative integer", ptr+"/"+k)
+		}
+		schema[k] = v
+		if k == "minLength" {
+			min = int(f)
+		} else {
+			max = int(f)
+		}
+	}
+
+	var c constraints.Constraint[string]
+	switch {
+	case min >= 0 && max >= 0:
+		c = stdtypes.StringRuneLengthRange(min, max)
+	case min >= 0:
+		c = stdtypes.StringRuneMinLength(min)
+	default:
+		c = stdtypes.StringRuneMaxLength(max)
+	}
+	return &adapted[string]{c: c, convert: toString, schema: schema}, nil
+}
+
+func parsePattern(v any, ptr string) (constraints.Constraint[any], error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, fmt.Errorf("jsonschema: %s: must be a string", pointer(ptr))
+	}
+	re, err := regexp.Compile(s)
+	if err != nil {
+		return nil, fmt.Errorf("jsonschema: %s: %w", pointer(ptr), err)
+	}
+	return &adapted[string]{
+		c:       stdtypes.StringPattern(re),
+		convert: toString,
+		schema:  Schema{"pattern": s},
+	}, nil
+}
+
+// jsonOneOf creates a constraint which compares values by their
+// canonical JSON encoding. It's used for enum and const.
+func jsonOneOf(options []any) constraints.Constraint[any] {
+	encoded := make([]string, 0, len(options))
+	for _, o := range options {
+		encoded = append(encoded, canonicalJSON(o))
+	}
+	return &adapted[string]{
+		c:       constraints.OneOf(encoded...),
+		convert: toCanonicalJSON,
+		schema:  Schema{"enum": options},
+	}
+}
+
+func jsonEqual(value any) constraints.Constraint[any] {
+	return &adapted[string]{
+		c:       constraints.Match(canonicalJSON(value)),
+		convert: toCanonicalJSON,
+		schema:  Schema{"const": value},
+	}
+}
+
+func canonicalJSON(v any) string {
+	// encoding/json sorts the keys of maps thus the result is stable.
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%#v", v)
+	}
+	return string(b)
+}
+
+func toCanonicalJSON(v any) (string, bool) { return canonicalJSON(v), true }
+
+func identity(v any) (any, bool) { return v, true }
+
+func toString(v any) (string, bool) {
+	s, ok := v.(string)
+	return s, ok
+}
+
+func toFloat64(v any) (float64, bool) {
+	switch tv := v.(type) {
+	case float64:
+		return tv, true
+	case float32:
+		return float64(tv), true
+	case int:
+		return float64(tv), true
+	case int8:
+		return float64(tv), true
+	case int16:
+		return float64(tv), true
+	case int32:
+		return float64(tv), true
+	case int64:
+		return float64(tv), true
+	case uint:
+		return float64(tv), true
+	case uint8:
+		return float64(tv), true
+	case uint16:
+		return float64(tv), true
+	case uint32:
+		return float64(tv), true
+	case uint64:
+		return float64(tv), true
+	case json.Number:
+		f, err := tv.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// adapted applies a typed constraint to decoded JSON values. Following
+// JSON Schema semantics, values which couldn't be converted into ValueT
+// are considered as valid, e.g., minimum doesn't apply to strings.
+type adapted[ValueT any] struct {
+	c       constraints.Constraint[ValueT]
+	convert func(v any) (ValueT, bool)
+	schema  Schema
+}
+
+var (
+	_ constraints.Constraint[any]  = &adapted[string]{}
+	_ constraints.KindedConstraint = &adapted[string]{}
+	_ SchemaProvider               = &adapted[string]{}
+)
+
+func (c *adapted[ValueT]) ConstraintDescription() string {
+	return c.c.ConstraintDescription()
+}
+
+func (c *adapted[ValueT]) ConstraintKind() constraints.Kind {
+	return constraints.KindOf(c.c)
+}
+
+func (c *adapted[ValueT]) IsValid(v any) bool {
+	tv, ok := c.convert(v)
+	if !ok {
+		return true
+	}
+	return c.c.IsValid(tv)
+}
+
+// JSONSchema conforms SchemaProvider interface.
+func (c *adapted[ValueT]) JSONSchema() Schema {
+	s := make(Schema, len(c.schema))
+	for k, v := range c.schema {
+		s[k] = v
+	}
+	return s
+}
+
+func pointer(ptr string) string {
+	if ptr == "" {
+		return "/"
+	}
+	return ptr
+}
+
+func escapePointer(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,97 @@
+package jsonschema
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rez-go/constraints"
+	"github.com/rez-go/constraints/stdtypes"
+)
+
+func TestParse(t *testing.T) {
+	c, err := Parse([]byte(`{
+		"title": "username",
+		"type": "string",
+		"minLength": 6,
+		"maxLength": 32,
+		"pattern": "^[A-Za-z0-9_]+$",
+		"not": {"enum": ["administrator", "root_user"]}
+	}`))
+	assertEq(t, nil, err)
+	assertEq(t, true, c.IsValid("hello_world"))
+	assertEq(t, false, c.IsValid("hello"))
+	assertEq(t, false, c.IsValid("hello world"))
+	assertEq(t, false, c.IsValid("administrator"))
+	assertEq(t, false, c.IsValid(123456.0))
+
+	cs, ok := c.(constraints.ConstraintSet[any, constraints.Constraint[any]])
+	assertEq(t, true, ok)
+	violated := cs.ValidateAll("root_user")
+	assertEq(t, 1, len(violated))
+	assertEq(t, constraints.KindNegate, constraints.KindOf(violated[0]))
+}
+
+func TestParseLengthInRunes(t *testing.T) {
+	c, err := Parse([]byte(`{"minLength": 3, "maxLength": 4}`))
+	assertEq(t, nil, err)
+	assertEq(t, true, c.IsValid("日本語"))
+	assertEq(t, false, c.IsValid("日本"))
+	assertEq(t, false, c.IsValid("日本語です"))
+	assertEq(t, stdtypes.KindRuneLengthRange, constraints.KindOf(c))
+}
+
+func TestParseNumeric(t *testing.T) {
+	c, err := FromMap(map[string]any{
+		"anyOf": []any{
+			map[string]any{"minimum": 0, "exclusiveMaximum": 10},
+			map[string]any{"const": 100},
+		},
+	})
+	assertEq(t, nil, err)
+	assertEq(t, true, c.IsValid(0.0))
+	assertEq(t, true, c.IsValid(9.5))
+	assertEq(t, false, c.IsValid(10.0))
+	assertEq(t, false, c.IsValid(-1))
+	assertEq(t, true, c.IsValid(100))
+	// Numeric keywords don't apply to strings.
+	assertEq(t, true, c.IsValid("ten"))
+	assertEq(t, "min 0, less than 10 or match \"100\"", c.ConstraintDescription())
+}
+
+func TestParseRoundTrip(t *testing.T) {
+	c, err := Parse([]byte(`{"allOf":[{"maximum":5,"minimum":1},{"type":"integer"}]}`))
+	assertEq(t, nil, err)
+	assertEq(t, `{"allOf":[{"maximum":5,"minimum":1},{"type":"integer"}]}`,
+		marshal(t, Convert(c)))
+}
+
+func TestParseConditional(t *testing.T) {
+	c, err := Parse([]byte(`{
+		"if": {"const": "ID"},
+		"then": {"minLength": 5},
+		"else": {"maxLength": 3}
+	}`))
+	assertEq(t, nil, err)
+	assertEq(t, constraints.KindWhen, constraints.KindOf(c))
+	assertEq(t, false, c.IsValid("ID"))
+	assertEq(t, false, c.IsValid("ABCDEF"))
+	assertEq(t, true, c.IsValid("AB"))
+	assertEq(t, `{"else":{"maxLength":3},"if":{"const":"ID"},"then":{"minLength":5}}`,
+		marshal(t, Convert(c)))
+}
+
+func TestParseUnsupported(t *testing.T) {
+	_, err := Parse([]byte(`{"properties":{},"allOf":[{"oneOf":[true]}],"dependentSchemas":{}}`))
+	var ue *UnsupportedKeywordsError
+	assertEq(t, true, errors.As(err, &ue))
+	assertEq(t, []string{"/allOf/0/oneOf", "/dependentSchemas", "/properties"}, ue.Keywords)
+	assertEq(t, "jsonschema: unsupported keywords: /allOf/0/oneOf, /dependentSchemas, /properties",
+		err.Error())
+}
+
+func TestParseInvalid(t *testing.T) {
+	_, err := Parse([]byte(`{"anyOf":[{"minimum":"zero"}]}`))
+	assertEq(t, "jsonschema: /anyOf/0/minimum: must be a number", err.Error())
+	_, err = Parse([]byte(`{"pattern":"("}`))
+	assertEq(t, true, err != nil)
+}
@@ -0,0 +1,107 @@
+package constraints
+
+// A Kind identifies the type of a constraint, e.g., "min" or "one_of".
+//
+// Kinds are plain strings so that other packages could define their own
+// kinds without risking collisions with the built-in ones. By convention,
+// kinds from other packages are prefixed with a namespace followed by
+// a dot, e.g., "string.prefix".
+//
+// API status: experimental
+type Kind string
+
+// Kinds of the built-in constraints.
+const (
+	KindUnknown Kind = ""
+
+	KindFunc   Kind = "func"
+	KindNegate Kind = "not"
+	KindSet    Kind = "set"
+	KindAny    Kind = "any"
+	KindStruct Kind = "struct"
+	KindField  Kind = "field"
+	KindFields Kind = "fields"
+
+	KindWhen         Kind = "when"
+	KindWhenBranch   Kind = "when.branch"
+	KindRequiredIf   Kind = "required_if"
+	KindRequiredWith Kind = "required_with"
+
+	KindDependsOn Kind = "depends_on"
+
+	KindUnique Kind = "unique"
+	KindExists Kind = "exists"
+
+	KindMatch  Kind = "match"
+	KindOneOf  Kind = "one_of"
+	KindNoneOf Kind = "none_of"
+	KindRange  Kind = "range"
+
+	KindMin                  Kind = "min"
+	KindMax                  Kind = "max"
+	KindEqualTo              Kind = "equal_to"
+	KindNotEqualTo           Kind = "not_equal_to"
+	KindLessThan             Kind = "less_than"
+	KindLessThanOrEqualTo    Kind = "less_than_or_equal_to"
+	KindGreaterThan          Kind = "greater_than"
+	KindGreaterThanOrEqualTo Kind = "greater_than_or_equal_to"
+)
+
+// KindedConstraint is implemented by constraints which are able to
+// report their Kind.
+type KindedConstraint interface {
+	ConstraintBase
+
+	// ConstraintKind returns the kind of the constraint.
+	ConstraintKind() Kind
+}
+
+// KindOf returns the Kind of constraint c. It returns KindUnknown if
+// c doesn't provide the information.
+func KindOf(c ConstraintBase) Kind {
+	if kc, ok := c.(KindedConstraint); ok && kc != nil {
+		return kc.ConstraintKind()
+	}
+	return KindUnknown
+}
+
+// Bounds describes the lower and the upper limits of a constraint.
+type Bounds[ValueT any] struct {
+	Min    ValueT
+	Max    ValueT
+	HasMin bool
+	HasMax bool
+	// MinExclusive is true if a value equals to Min is considered
+	// as invalid.
+	MinExclusive bool
+	// MaxExclusive is true if a value equals to Max is considered
+	// as invalid.
+	MaxExclusive bool
+}
+
+// BoundedConstraint is implemented by constraints which limit values
+// to a lower and/or an upper bound, e.g., Min, Max and Range.
+type BoundedConstraint[ValueT any] interface {
+	Constraint[ValueT]
+
+	// Bounds returns the limits of the constraint.
+	Bounds() Bounds[ValueT]
+}
+
+// OptionsConstraint is implemented by choice-based constraints,
+// e.g., OneOf and NoneOf.
+type OptionsConstraint[ValueT any] interface {
+	Constraint[ValueT]
+
+	// Options returns a copy of the options of the constraint.
+	Options() []ValueT
+}
+
+// OperandConstraint is implemented by constraints which compare values
+// against a reference value, e.g., Match and LessThan.
+type OperandConstraint[ValueT any] interface {
+	Constraint[ValueT]
+
+	// Operand returns the reference value of the constraint.
+	Operand() ValueT
+}
@@ -0,0 +1,42 @@
+package constraints
+
+import "testing"
+
+func TestKindOf(t *testing.T) {
+	assertEq(t, KindMin, KindOf(Min(5)))
+	assertEq(t, KindMax, KindOf(Max(5)))
+	assertEq(t, KindLessThan, KindOf(LessThan(5)))
+	assertEq(t, KindGreaterThanOrEqualTo, KindOf(GreaterThanOrEqualTo(5)))
+	assertEq(t, KindRange, KindOf(Range(1, 2)))
+	assertEq(t, KindMatch, KindOf(Match("a")))
+	assertEq(t, KindOneOf, KindOf(OneOf("a", "b")))
+	assertEq(t, KindNoneOf, KindOf(NoneOf("a", "b")))
+	assertEq(t, KindFunc, KindOf(Func("any", func(int) bool { return true })))
+	assertEq(t, KindSet, KindOf(Set[int](Min(1))))
+	assertEq(t, KindAny, KindOf(Any[int](Min(1))))
+	assertEq(t, KindUnknown, KindOf(nil))
+}
+
+func TestBounds(t *testing.T) {
+	assertEq(t, Bounds[int]{Min: 5, HasMin: true},
+		Min(5).(BoundedConstraint[int]).Bounds())
+	assertEq(t, Bounds[int]{Max: 5, HasMax: true},
+		Max(5).(BoundedConstraint[int]).Bounds())
+	assertEq(t, Bounds[int]{Max: 5, HasMax: true, MaxExclusive: true},
+		LessThan(5).(BoundedConstraint[int]).Bounds())
+	assertEq(t, Bounds[int]{Min: 5, HasMin: true, MinExclusive: true},
+		GreaterThan(5).(BoundedConstraint[int]).Bounds())
+	assertEq(t, Bounds[int]{Min: 1, Max: 10, HasMin: true, HasMax: true},
+		Range(1, 10).(BoundedConstraint[int]).Bounds())
+}
+
+func TestOptionsAndOperand(t *testing.T) {
+	options := []string{"a", "b"}
+	c := OneOf(options...)
+	options[0] = "z"
+	assertEq(t, []string{"a", "b"}, c.(OptionsConstraint[string]).Options())
+	assertEq(t, true, c.IsValid("a"))
+	assertEq(t, false, c.IsValid("z"))
+	assertEq(t, "hello", Match("hello").Operand())
+	assertEq(t, 5, Min(5).(OperandConstraint[int]).Operand())
+}
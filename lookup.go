@@ -0,0 +1,154 @@
+package constraints
+
+import (
+	"context"
+	"sync"
+)
+
+// A Lookup tells whether keys exist in a store, e.g., the usernames
+// already taken or the entries of a denylist.
+//
+// API status: experimental
+type Lookup[KeyT any] interface {
+	// Exists returns true if key exists. It returns an error if it
+	// could not tell.
+	Exists(ctx context.Context, key KeyT) (bool, error)
+}
+
+// Unique creates a ContextConstraint which declares a value as valid
+// if it doesn't exist in lookup l, e.g., a username which has not been
+// taken.
+//
+// The constraint could be given a violation code and a localizable
+// message with the WithCode and WithMessage options, e.g., to describe
+// a denylist.
+//
+// API status: experimental
+func Unique[ValueT any](l Lookup[ValueT], opts ...Option) ContextConstraint[ValueT] {
+	c := &lookupConstraint[ValueT]{lookup: l, kind: KindUnique, desc: "unique"}
+	c.options.apply(opts)
+	return c
+}
+
+// ExistsIn creates a ContextConstraint which declares a value as valid
+// if it exists in lookup l, e.g., an ID of an existing record.
+//
+// API status: experimental
+func ExistsIn[ValueT any](l Lookup[ValueT], opts ...Option) ContextConstraint[ValueT] {
+	c := &lookupConstraint[ValueT]{lookup: l, kind: KindExists, desc: "exists", exists: true}
+	c.options.apply(opts)
+	return c
+}
+
+type lookupConstraint[ValueT any] struct {
+	options
+	lookup Lookup[ValueT]
+	kind   Kind
+	desc   string
+	// exists is true if the values must exist in the lookup.
+	exists bool
+}
+
+var (
+	_ ContextConstraint[string] = &lookupConstraint[string]{}
+	_ KindedConstraint          = &lookupConstraint[string]{}
+	_ MessageConstraint         = &lookupConstraint[string]{}
+	_ CodedConstraint           = &lookupConstraint[string]{}
+)
+
+// ConstraintDescription conforms Constraint interface.
+func (c *lookupConstraint[ValueT]) ConstraintDescription() string {
+	return c.desc
+}
+
+// ConstraintKind conforms KindedConstraint interface.
+func (c *lookupConstraint[ValueT]) ConstraintKind() Kind {
+	return c.kind
+}
+
+// ConstraintMessage conforms MessageConstraint interface.
+func (c *lookupConstraint[ValueT]) ConstraintMessage() Message {
+	return c.message(Message{ID: string(c.kind), Text: c.desc})
+}
+
+// ConstraintCode conforms CodedConstraint interface.
+func (c *lookupConstraint[ValueT]) ConstraintCode() string {
+	return c.code(c.ConstraintMessage().ID)
+}
+
+// Check conforms ContextConstraint interface.
+func (c *lookupConstraint[ValueT]) Check(ctx context.Context, v ValueT) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	exists, err := c.lookup.Exists(ctx, v)
+	if err != nil {
+		return err
+	}
+	if exists != c.exists {
+		return ContextViolationError[ValueT](c)
+	}
+	return nil
+}
+
+// A MemoryLookup is an in-memory Lookup. It's a stand-in for
+// store-backed lookups in tests. A MemoryLookup is safe for
+// concurrent use.
+//
+// API status: experimental
+type MemoryLookup[KeyT comparable] struct {
+	mu   sync.RWMutex
+	keys map[KeyT]struct{}
+	err  error
+}
+
+var _ Lookup[string] = &MemoryLookup[string]{}
+
+// NewMemoryLookup creates a MemoryLookup which contains keys.
+func NewMemoryLookup[KeyT comparable](keys ...KeyT) *MemoryLookup[KeyT] {
+	l := &MemoryLookup[KeyT]{keys: make(map[KeyT]struct{}, len(keys))}
+	for _, k := range keys {
+		l.keys[k] = struct{}{}
+	}
+	return l
+}
+
+// Add adds keys to the lookup.
+func (l *MemoryLookup[KeyT]) Add(keys ...KeyT) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, k := range keys {
+		l.keys[k] = struct{}{}
+	}
+}
+
+// Remove removes keys from the lookup.
+func (l *MemoryLookup[KeyT]) Remove(keys ...KeyT) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, k := range keys {
+		delete(l.keys, k)
+	}
+}
+
+// SetError makes subsequent calls to Exists fail with err, e.g., to
+// simulate an unreachable store. Pass nil to restore.
+func (l *MemoryLookup[KeyT]) SetError(err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.err = err
+}
+
+// Exists conforms Lookup interface.
+func (l *MemoryLookup[KeyT]) Exists(ctx context.Context, key KeyT) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.err != nil {
+		return false, l.err
+	}
+	_, ok := l.keys[key]
+	return ok, nil
+}
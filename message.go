@@ -0,0 +1,73 @@
+package constraints
+
+// A Message is a localizable description of a constraint. The
+// presentation layer could use the ID to look up a translated template
+// and render it with the Args.
+//
+// API status: experimental
+type Message struct {
+	// ID is the stable identifier of the message, e.g., "length.min".
+	// It's empty for free-form descriptions, e.g., those of constraints
+	// created with Func without a message option.
+	ID string
+	// Args holds the named arguments of the message, e.g., "min". Values
+	// of type Message and []Message are messages of the constraints
+	// a composite constraint is constructed of.
+	Args map[string]any
+	// Text is the English description of the constraint. It's the same
+	// as the result of ConstraintDescription.
+	Text string
+
+	// code is the violation code of the constraint if it differs from
+	// ID, e.g., when set with WithCode. It's used to encode the messages
+	// of nested constraints in a Violation.
+	code string
+}
+
+// MessageConstraint is implemented by constraints which are able to
+// describe themselves as a localizable Message.
+type MessageConstraint interface {
+	ConstraintBase
+
+	// ConstraintMessage returns the localizable description of
+	// the constraint.
+	ConstraintMessage() Message
+}
+
+// MessageOf returns the localizable description of constraint c. If c
+// doesn't implement MessageConstraint, the result contains only the Text.
+// The messages of the members of composite constraints should be
+// obtained with MessageOf so that their codes are kept.
+func MessageOf(c ConstraintBase) Message {
+	if c == nil {
+		return Message{}
+	}
+	var msg Message
+	if mc, ok := c.(MessageConstraint); ok {
+		msg = mc.ConstraintMessage()
+	} else {
+		msg = Message{Text: c.ConstraintDescription()}
+	}
+	if cc, ok := c.(CodedConstraint); ok {
+		if code := cc.ConstraintCode(); code != msg.ID {
+			msg.code = code
+		}
+	}
+	return msg
+}
+
+func messageList[ValueT any](list []Constraint[ValueT]) []Message {
+	msgs := make([]Message, 0, len(list))
+	for _, c := range list {
+		msgs = append(msgs, MessageOf(c))
+	}
+	return msgs
+}
+
+func anyList[ValueT any](values []ValueT) []any {
+	result := make([]any, 0, len(values))
+	for _, v := range values {
+		result = append(result, v)
+	}
+	return result
+}
@@ -0,0 +1,30 @@
+package constraints
+
+import "testing"
+
+func TestMessageOf(t *testing.T) {
+	assertEq(t, Message{ID: "min", Args: map[string]any{"min": 5}, Text: "min 5"},
+		MessageOf(Min(5)))
+	assertEq(t, Message{
+		ID:   "range",
+		Args: map[string]any{"min": 'a', "max": 'z'},
+		Text: "from 'a' to 'z'",
+	}, MessageOf(Range('a', 'z')))
+	assertEq(t, Message{Text: "anything"},
+		MessageOf(Func("anything", func(int) bool { return true })))
+	assertEq(t, Message{ID: "anything", Args: map[string]any{"n": 1}, Text: "anything"},
+		MessageOf(Func("anything", func(int) bool { return true },
+			WithMessage("anything", map[string]any{"n": 1}))))
+}
+
+func TestMessageOfComposite(t *testing.T) {
+	c := Set[int](Min(1), Negate[int](Match(5), ""), Negate[int](Match(6), "not six"))
+	msg := MessageOf(c)
+	assertEq(t, "set", msg.ID)
+	children := msg.Args["constraints"].([]Message)
+	assertEq(t, 3, len(children))
+	assertEq(t, "not", children[1].ID)
+	assertEq(t, "match", children[1].Args["constraint"].(Message).ID)
+	assertEq(t, "", children[2].ID)
+	assertEq(t, "not six", children[2].Text)
+}
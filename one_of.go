@@ -12,13 +12,13 @@ import (
 func OneOf[ValueT comparable](options ...ValueT) Constraint[ValueT] {
 	copies := make([]ValueT, len(options))
 	copy(copies, options)
-	return &oneOfConstraint[ValueT]{negate: false, options: options}
+	return &oneOfConstraint[ValueT]{negate: false, options: copies}
 }
 
 func NoneOf[ValueT comparable](options ...ValueT) Constraint[ValueT] {
 	copies := make([]ValueT, len(options))
 	copy(copies, options)
-	return &oneOfConstraint[ValueT]{negate: true, options: options}
+	return &oneOfConstraint[ValueT]{negate: true, options: copies}
 }
 
 // oneOfConstraint defines choice-based Constraint.
@@ -28,7 +28,10 @@ type oneOfConstraint[ValueT comparable] struct {
 }
 
 var (
-	_ Constraint[string] = oneOfConstraint[string]{}
+	_ Constraint[string]        = oneOfConstraint[string]{}
+	_ OptionsConstraint[string] = oneOfConstraint[string]{}
+	_ KindedConstraint          = oneOfConstraint[string]{}
+	_ MessageConstraint         = oneOfConstraint[string]{}
 )
 
 // ConstraintDescription conforms constraints.Constraint interface.
@@ -44,6 +47,30 @@ func (c oneOfConstraint[ValueT]) ConstraintDescription() string {
 	return fmt.Sprintf("one of %v", opt)
 }
 
+// ConstraintKind conforms KindedConstraint interface.
+func (c oneOfConstraint[ValueT]) ConstraintKind() Kind {
+	if c.negate {
+		return KindNoneOf
+	}
+	return KindOneOf
+}
+
+// ConstraintMessage conforms MessageConstraint interface.
+func (c oneOfConstraint[ValueT]) ConstraintMessage() Message {
+	return Message{
+		ID:   string(c.ConstraintKind()),
+		Args: map[string]any{"options": anyList(c.options)},
+		Text: c.ConstraintDescription(),
+	}
+}
+
+// Options conforms OptionsConstraint interface.
+func (c oneOfConstraint[ValueT]) Options() []ValueT {
+	copies := make([]ValueT, len(c.options))
+	copy(copies, c.options)
+	return copies
+}
+
 // IsValid conforms Constraint interface.
 func (c oneOfConstraint[ValueT]) IsValid(v ValueT) bool {
 	for _, s := range c.options {
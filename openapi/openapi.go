@@ -0,0 +1,100 @@
+// Package openapi generates OpenAPI 3.1 fragments from constraints.
+//
+// OpenAPI 3.1 Schema Objects are a superset of JSON Schema draft 2020-12,
+// thus the schemas are generated by package jsonschema.
+//
+// API status: experimental
+package openapi
+
+import (
+	"github.com/rez-go/constraints"
+	"github.com/rez-go/constraints/jsonschema"
+)
+
+// A ParameterLocation is the location of a Parameter.
+type ParameterLocation string
+
+// Supported parameter locations.
+const (
+	InQuery  ParameterLocation = "query"
+	InHeader ParameterLocation = "header"
+	InPath   ParameterLocation = "path"
+	InCookie ParameterLocation = "cookie"
+)
+
+// A Parameter is an OpenAPI Parameter Object.
+type Parameter struct {
+	Name        string            `json:"name"`
+	In          ParameterLocation `json:"in"`
+	Description string            `json:"description,omitempty"`
+	Required    bool              `json:"required,omitempty"`
+	Schema      jsonschema.Schema `json:"schema"`
+}
+
+// NewParameter creates a Parameter named name from constraint c. Path
+// parameters are always required as mandated by the specification.
+func NewParameter[ValueT any](
+	name string,
+	in ParameterLocation,
+	required bool,
+	c constraints.Constraint[ValueT],
+) Parameter {
+	return Parameter{
+		Name:        name,
+		In:          in,
+		Description: description(c),
+		Required:    required || in == InPath,
+		Schema:      Schema(c),
+	}
+}
+
+// Schema creates a Schema Object from constraint c. The description
+// of the schema is derived from the description of c.
+func Schema[ValueT any](c constraints.Constraint[ValueT]) jsonschema.Schema {
+	s := jsonschema.Flatten(jsonschema.Convert(c))
+	if desc := description(c); desc != "" {
+		s["description"] = desc
+	}
+	return s
+}
+
+// A Field describes a property of an object schema.
+type Field struct {
+	Name     string
+	Required bool
+	Schema   jsonschema.Schema
+}
+
+// NewField creates a Field named name from constraint c.
+func NewField[ValueT any](
+	name string,
+	required bool,
+	c constraints.Constraint[ValueT],
+) Field {
+	return Field{Name: name, Required: required, Schema: Schema(c)}
+}
+
+// Object creates a Schema Object of an object which has the fields
+// as its properties.
+func Object(fields ...Field) jsonschema.Schema {
+	props := make(map[string]jsonschema.Schema, len(fields))
+	var required []string
+	for _, f := range fields {
+		props[f.Name] = f.Schema
+		if f.Required {
+			required = append(required, f.Name)
+		}
+	}
+	s := jsonschema.Schema{"type": "object", "properties": props}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
+
+func description(c constraints.ConstraintBase) string {
+	if c == nil {
+		return ""
+	}
+	return c.ConstraintDescription()
+}
@@ -0,0 +1,53 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/rez-go/constraints"
+	"github.com/rez-go/constraints/stdtypes"
+)
+
+func marshal(t *testing.T, v any) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func assertJSON(t *testing.T, expected string, v any) {
+	t.Helper()
+	if actual := marshal(t, v); actual != expected {
+		t.Fatalf("\n\tExpected: %s\n\tActual: %s", expected, actual)
+	}
+}
+
+func TestNewParameter(t *testing.T) {
+	sortOrder := constraints.OneOf("asc", "desc")
+	assertJSON(t,
+		`{"name":"order","in":"query","description":"one of [asc, desc]",`+
+			`"schema":{"description":"one of [asc, desc]","enum":["asc","desc"],"type":"string"}}`,
+		NewParameter("order", InQuery, false, sortOrder))
+	assertJSON(t,
+		`{"name":"id","in":"path","description":"min 1","required":true,`+
+			`"schema":{"description":"min 1","minimum":1,"type":"integer"}}`,
+		NewParameter[int]("id", InPath, false, constraints.Min(1)))
+}
+
+func TestObject(t *testing.T) {
+	username := constraints.Set(
+		stdtypes.StringRuneMinLength(6),
+		stdtypes.StringRuneMaxLength(32),
+	)
+	age := constraints.Range(13, 130)
+	assertJSON(t,
+		`{"properties":{`+
+			`"age":{"description":"from 13 to 130","maximum":130,"minimum":13,"type":"integer"},`+
+			`"username":{"description":"min rune length 6, max rune length 32","maxLength":32,"minLength":6,"type":"string"}},`+
+			`"required":["username"],"type":"object"}`,
+		Object(
+			NewField[string]("username", true, username),
+			NewField("age", false, age)))
+}
@@ -0,0 +1,68 @@
+package constraints
+
+// An Option configures a constraint created by Func, Negate, CheckFunc,
+// Unique or ExistsIn.
+//
+// WithCost has no effect on CheckFunc, Unique and ExistsIn.
+type Option func(*options)
+
+type options struct {
+	codeOverride string
+	msgID        string
+	msgArgs      map[string]any
+	msgSet       bool
+	cost         Cost
+}
+
+// WithCode sets the violation code of the constraint. See CodeOf.
+func WithCode(code string) Option {
+	return func(o *options) {
+		o.codeOverride = code
+	}
+}
+
+// WithMessage sets the message ID and the message arguments of
+// the constraint. See Message.
+func WithMessage(id string, args map[string]any) Option {
+	return func(o *options) {
+		o.msgID = id
+		o.msgArgs = args
+		o.msgSet = true
+	}
+}
+
+func (o *options) apply(opts []Option) {
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+}
+
+// message returns msg with ID and Args replaced if they were set
+// through WithMessage.
+func (o options) message(msg Message) Message {
+	if o.msgSet {
+		msg.ID = o.msgID
+		msg.Args = o.msgArgs
+	}
+	return msg
+}
+
+// costOr returns the cost set through WithCost, or defaultCost if
+// there's none.
+func (o options) costOr(defaultCost Cost) Cost {
+	if o.cost != 0 {
+		return o.cost
+	}
+	return defaultCost
+}
+
+// code returns the code set through WithCode, or defaultCode if there's
+// none.
+func (o options) code(defaultCode string) string {
+	if o.codeOverride != "" {
+		return o.codeOverride
+	}
+	return defaultCode
+}
@@ -11,9 +11,7 @@ import (
 func Min[
 	ValueT typecons.Ordered,
 ](refValue ValueT) OrderedConstraint[ValueT] {
-	return Func(
-		fmt.Sprintf("min %v", refValue),
-		GreaterThanOrEqualTo(refValue).IsValid)
+	return &relOpConstraint[ValueT]{ref: refValue, op: RelOpGreaterOrEqual, kind: KindMin}
 }
 
 // Max creates a Constraint which will declare an instance is valid
@@ -21,9 +19,7 @@ func Min[
 func Max[
 	ValueT typecons.Ordered,
 ](refValue ValueT) OrderedConstraint[ValueT] {
-	return Func(
-		fmt.Sprintf("max %v", refValue),
-		LessThanOrEqualTo(refValue).IsValid)
+	return &relOpConstraint[ValueT]{ref: refValue, op: RelOpLessOrEqual, kind: KindMax}
 }
 
 // LessThan creates an Constraint which an instance will be
@@ -31,7 +27,7 @@ func Max[
 func LessThan[
 	ValueT typecons.Ordered,
 ](refValue ValueT) OrderedConstraint[ValueT] {
-	return &relOpConstraint[ValueT]{ref: refValue, op: relOpLess}
+	return &relOpConstraint[ValueT]{ref: refValue, op: RelOpLess}
 }
 
 // LessThanOrEqualTo creates an Constraint which an instance will be
@@ -39,7 +35,7 @@ func LessThan[
 func LessThanOrEqualTo[
 	ValueT typecons.Ordered,
 ](refValue ValueT) OrderedConstraint[ValueT] {
-	return &relOpConstraint[ValueT]{ref: refValue, op: relOpLessOrEqual}
+	return &relOpConstraint[ValueT]{ref: refValue, op: RelOpLessOrEqual}
 }
 
 // GreaterThan creates an Constraint which an instance will be declared
@@ -47,7 +43,7 @@ func LessThanOrEqualTo[
 func GreaterThan[
 	ValueT typecons.Ordered,
 ](refValue ValueT) OrderedConstraint[ValueT] {
-	return &relOpConstraint[ValueT]{ref: refValue, op: relOpGreater}
+	return &relOpConstraint[ValueT]{ref: refValue, op: RelOpGreater}
 }
 
 // GreaterThanOrEqualTo creates an Constraint which an instance will be
@@ -55,7 +51,7 @@ func GreaterThan[
 func GreaterThanOrEqualTo[
 	ValueT typecons.Ordered,
 ](refValue ValueT) OrderedConstraint[ValueT] {
-	return &relOpConstraint[ValueT]{ref: refValue, op: relOpGreaterOrEqual}
+	return &relOpConstraint[ValueT]{ref: refValue, op: RelOpGreaterOrEqual}
 }
 
 type OrderedConstraint[ValueT typecons.Ordered] interface {
@@ -65,80 +61,185 @@ type OrderedConstraint[ValueT typecons.Ordered] interface {
 var (
 	_ OrderedConstraint[int] = &relOpConstraint[int]{}
 	_ OrderedConstraint[int] = relOpConstraint[int]{}
+	_ BoundedConstraint[int] = relOpConstraint[int]{}
+	_ OperandConstraint[int] = relOpConstraint[int]{}
+	_ KindedConstraint       = relOpConstraint[int]{}
+	_ MessageConstraint      = relOpConstraint[int]{}
 )
 
 type relOpConstraint[ValueT typecons.Ordered] struct {
-	op  relOp
+	op  RelOp
 	ref ValueT
+	// kind overrides the kind derived from op. Used by Min and Max.
+	kind Kind
 }
 
 func (c relOpConstraint[ValueT]) ConstraintDescription() string {
+	switch c.kind {
+	case KindMin:
+		return fmt.Sprintf("min %v", c.ref)
+	case KindMax:
+		return fmt.Sprintf("max %v", c.ref)
+	}
 	return fmt.Sprintf(c.op.StringFormat(), c.ref)
 }
 
-func (c relOpConstraint[ValueT]) IsValid(v ValueT) bool {
+// ConstraintKind conforms KindedConstraint interface.
+func (c relOpConstraint[ValueT]) ConstraintKind() Kind {
+	if c.kind != KindUnknown {
+		return c.kind
+	}
+	return c.op.Kind()
+}
+
+// ConstraintMessage conforms MessageConstraint interface.
+func (c relOpConstraint[ValueT]) ConstraintMessage() Message {
+	kind := c.ConstraintKind()
+	var args map[string]any
+	switch kind {
+	case KindMin:
+		args = map[string]any{"min": c.ref}
+	case KindMax:
+		args = map[string]any{"max": c.ref}
+	default:
+		args = map[string]any{"value": c.ref}
+	}
+	return Message{ID: string(kind), Args: args, Text: c.ConstraintDescription()}
+}
+
+// Operand conforms OperandConstraint interface.
+func (c relOpConstraint[ValueT]) Operand() ValueT {
+	return c.ref
+}
+
+// Bounds conforms BoundedConstraint interface. Equality operators
+// have no bounds.
+func (c relOpConstraint[ValueT]) Bounds() Bounds[ValueT] {
 	switch c.op {
-	case relOpEqual:
-		return v == c.ref
-	case relOpNotEqual:
-		return v != c.ref
-	case relOpLess:
-		return v < c.ref
-	case relOpLessOrEqual:
-		return v <= c.ref
-	case relOpGreater:
-		return v > c.ref
-	case relOpGreaterOrEqual:
-		return v >= c.ref
+	case RelOpLess:
+		return Bounds[ValueT]{Max: c.ref, HasMax: true, MaxExclusive: true}
+	case RelOpLessOrEqual:
+		return Bounds[ValueT]{Max: c.ref, HasMax: true}
+	case RelOpGreater:
+		return Bounds[ValueT]{Min: c.ref, HasMin: true, MinExclusive: true}
+	case RelOpGreaterOrEqual:
+		return Bounds[ValueT]{Min: c.ref, HasMin: true}
+	}
+	return Bounds[ValueT]{}
+}
+
+func (c relOpConstraint[ValueT]) IsValid(v ValueT) bool {
+	return relOpHolds(c.op, v, c.ref)
+}
+
+// relOpHolds returns true if the relation op holds between a and b,
+// e.g., a < b for RelOpLess.
+func relOpHolds[ValueT typecons.Ordered](op RelOp, a, b ValueT) bool {
+	switch op {
+	case RelOpEqual:
+		return a == b
+	case RelOpNotEqual:
+		return a != b
+	case RelOpLess:
+		return a < b
+	case RelOpLessOrEqual:
+		return a <= b
+	case RelOpGreater:
+		return a > b
+	case RelOpGreaterOrEqual:
+		return a >= b
 	}
 	return false
 }
 
-// An relOp specifies relational operator.
-type relOp int
+// A RelOp specifies relational operator.
+//
+// API status: experimental
+type RelOp int
 
 // Supported relational operators.
 const (
-	relOpEqual relOp = iota
-	relOpNotEqual
-	relOpLess
-	relOpLessOrEqual
-	relOpGreater
-	relOpGreaterOrEqual
+	RelOpEqual RelOp = iota
+	RelOpNotEqual
+	RelOpLess
+	RelOpLessOrEqual
+	RelOpGreater
+	RelOpGreaterOrEqual
 )
 
-func (op relOp) String() string {
+// holds returns true if the relation holds for the result of
+// a three-way comparison, where cmp is negative, zero or positive if
+// the left operand is less than, equal to or greater than the right
+// operand respectively.
+func (op RelOp) holds(cmp int) bool {
 	switch op {
-	case relOpEqual:
+	case RelOpEqual:
+		return cmp == 0
+	case RelOpNotEqual:
+		return cmp != 0
+	case RelOpLess:
+		return cmp < 0
+	case RelOpLessOrEqual:
+		return cmp <= 0
+	case RelOpGreater:
+		return cmp > 0
+	case RelOpGreaterOrEqual:
+		return cmp >= 0
+	}
+	return false
+}
+
+func (op RelOp) String() string {
+	switch op {
+	case RelOpEqual:
 		return "equal"
-	case relOpNotEqual:
+	case RelOpNotEqual:
 		return "not equal"
-	case relOpLess:
+	case RelOpLess:
 		return "less"
-	case relOpLessOrEqual:
+	case RelOpLessOrEqual:
 		return "less or equal"
-	case relOpGreater:
+	case RelOpGreater:
 		return "greater"
-	case relOpGreaterOrEqual:
+	case RelOpGreaterOrEqual:
 		return "greater or equal"
 	}
 	return ""
 }
 
+// Kind returns the constraint Kind of the operator.
+func (op RelOp) Kind() Kind {
+	switch op {
+	case RelOpEqual:
+		return KindEqualTo
+	case RelOpNotEqual:
+		return KindNotEqualTo
+	case RelOpLess:
+		return KindLessThan
+	case RelOpLessOrEqual:
+		return KindLessThanOrEqualTo
+	case RelOpGreater:
+		return KindGreaterThan
+	case RelOpGreaterOrEqual:
+		return KindGreaterThanOrEqualTo
+	}
+	return KindUnknown
+}
+
 // Symbol returns representative symbol of the operator.
-func (op relOp) Symbol() string {
+func (op RelOp) Symbol() string {
 	switch op {
-	case relOpEqual:
+	case RelOpEqual:
 		return "="
-	case relOpNotEqual:
+	case RelOpNotEqual:
 		return "≠"
-	case relOpLess:
+	case RelOpLess:
 		return "<"
-	case relOpLessOrEqual:
+	case RelOpLessOrEqual:
 		return "≤"
-	case relOpGreater:
+	case RelOpGreater:
 		return ">"
-	case relOpGreaterOrEqual:
+	case RelOpGreaterOrEqual:
 		return "≥"
 	}
 	return "?"
@@ -146,19 +247,19 @@ func (op relOp) Symbol() string {
 
 // StringFormat returns a string which could be used to in *printf functions.
 // The string format expects a value to be passed.
-func (op relOp) StringFormat() string {
+func (op RelOp) StringFormat() string {
 	switch op {
-	case relOpEqual:
+	case RelOpEqual:
 		return "equals %v"
-	case relOpNotEqual:
+	case RelOpNotEqual:
 		return "not equal to %v"
-	case relOpLess:
+	case RelOpLess:
 		return "less than %v"
-	case relOpLessOrEqual:
+	case RelOpLessOrEqual:
 		return "less than or equal to %v"
-	case relOpGreater:
+	case RelOpGreater:
 		return "greater than %v"
-	case relOpGreaterOrEqual:
+	case RelOpGreaterOrEqual:
 		return "greater than or equal to %v"
 	}
 	return "(%v)"
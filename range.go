@@ -21,14 +21,43 @@ type rangeConstraint[ValueT typecons.Ordered] struct {
 }
 
 var (
-	_ Constraint[int] = rangeConstraint[int]{}
-	_ Constraint[int] = &rangeConstraint[int]{}
+	_ Constraint[int]        = rangeConstraint[int]{}
+	_ Constraint[int]        = &rangeConstraint[int]{}
+	_ BoundedConstraint[int] = rangeConstraint[int]{}
+	_ KindedConstraint       = rangeConstraint[int]{}
+	_ MessageConstraint      = rangeConstraint[int]{}
 )
 
 func (rc rangeConstraint[ValueT]) ConstraintDescription() string {
 	return fmt.Sprintf("from %v to %v", valueLiteralString(rc.min), valueLiteralString(rc.max))
 }
 
+// ConstraintKind conforms KindedConstraint interface.
+func (rc rangeConstraint[ValueT]) ConstraintKind() Kind {
+	return KindRange
+}
+
+// ConstraintMessage conforms MessageConstraint interface.
+func (rc rangeConstraint[ValueT]) ConstraintMessage() Message {
+	return Message{
+		ID:   string(KindRange),
+		Args: map[string]any{"min": rc.min, "max": rc.max},
+		Text: rc.ConstraintDescription(),
+	}
+}
+
+// Bounds conforms BoundedConstraint interface.
+func (rc rangeConstraint[ValueT]) Bounds() Bounds[ValueT] {
+	return Bounds[ValueT]{
+		Min:          rc.min,
+		Max:          rc.max,
+		HasMin:       true,
+		HasMax:       true,
+		MinExclusive: !rc.inclusive,
+		MaxExclusive: !rc.inclusive,
+	}
+}
+
 func (rc rangeConstraint[ValueT]) IsValid(v ValueT) bool {
 	if rc.inclusive {
 		return v >= rc.min && v <= rc.max
@@ -24,6 +24,9 @@ func Set[ValueT any](
 var (
 	_ Constraint[string]                        = constraintSet[string]{}
 	_ ConstraintSet[string, Constraint[string]] = constraintSet[string]{}
+	_ KindedConstraint                          = constraintSet[string]{}
+	_ CompositeConstraint[string]               = constraintSet[string]{}
+	_ MessageConstraint                         = constraintSet[string]{}
 )
 
 // constraintSet defines a set of constraints. A value is considered valid
@@ -44,16 +47,35 @@ func (cs constraintSet[ValueT]) ConstraintDescription() string {
 	return ""
 }
 
+// ConstraintKind conforms KindedConstraint interface.
+func (cs constraintSet[ValueT]) ConstraintKind() Kind {
+	return KindSet
+}
+
+// ConstraintMessage conforms MessageConstraint interface.
+func (cs constraintSet[ValueT]) ConstraintMessage() Message {
+	return Message{
+		ID:   string(KindSet),
+		Args: map[string]any{"constraints": messageList(cs.constraints)},
+		Text: cs.ConstraintDescription(),
+	}
+}
+
 // ConstraintList conforms Set interface.
 func (cs constraintSet[ValueT]) ConstraintList() []Constraint[ValueT] {
 	if cs.constraints != nil {
-		copyConstraints := make([]Constraint[ValueT], 0, len(cs.constraints))
+		copyConstraints := make([]Constraint[ValueT], len(cs.constraints))
 		copy(copyConstraints, cs.constraints)
 		return copyConstraints
 	}
 	return nil
 }
 
+// Children conforms CompositeConstraint interface.
+func (cs constraintSet[ValueT]) Children() []Constraint[ValueT] {
+	return cs.ConstraintList()
+}
+
 // IsValid conforms Constraint interface.
 func (cs constraintSet[ValueT]) IsValid(v ValueT) bool {
 	return cs.Validate(v) == nil
@@ -1,19 +1,59 @@
 package stdtypes
 
+import (
+	"github.com/rez-go/constraints"
+)
+
 var (
-	_ StringConstraint = targetOperandFuncConstraint[string]{}
+	_ StringConstraint                      = targetOperandFuncConstraint[string]{}
+	_ constraints.OperandConstraint[string] = targetOperandFuncConstraint[string]{}
+	_ constraints.KindedConstraint          = targetOperandFuncConstraint[string]{}
+	_ constraints.MessageConstraint         = targetOperandFuncConstraint[string]{}
+	_ constraints.CostedConstraint          = targetOperandFuncConstraint[string]{}
 )
 
 type targetOperandFuncConstraint[ValueT any] struct {
+	kind constraints.Kind
+	// argName is the name of the operand in the message arguments.
+	argName string
 	desc    string
 	operand ValueT
 	fn      func(target, operand ValueT) bool
+	// cost is the cost hint of the constraint. Zero means cheap.
+	cost constraints.Cost
 }
 
 func (c targetOperandFuncConstraint[ValueT]) ConstraintDescription() string {
 	return c.desc
 }
 
+// ConstraintKind conforms constraints.KindedConstraint interface.
+func (c targetOperandFuncConstraint[ValueT]) ConstraintKind() constraints.Kind {
+	return c.kind
+}
+
+// ConstraintMessage conforms constraints.MessageConstraint interface.
+func (c targetOperandFuncConstraint[ValueT]) ConstraintMessage() constraints.Message {
+	return constraints.Message{
+		ID:   string(c.kind),
+		Args: map[string]any{c.argName: c.operand},
+		Text: c.desc,
+	}
+}
+
+// ConstraintCost conforms constraints.CostedConstraint interface.
+func (c targetOperandFuncConstraint[ValueT]) ConstraintCost() constraints.Cost {
+	if c.cost == 0 {
+		return constraints.CostCheap
+	}
+	return c.cost
+}
+
+// Operand conforms constraints.OperandConstraint interface.
+func (c targetOperandFuncConstraint[ValueT]) Operand() ValueT {
+	return c.operand
+}
+
 func (c targetOperandFuncConstraint[ValueT]) IsValid(v ValueT) bool {
 	return c.fn != nil && c.fn(v, c.operand)
 }
@@ -0,0 +1,237 @@
+package stdtypes
+
+import "unicode"
+
+// graphemeBreak is the Grapheme_Cluster_Break property of a rune as
+// defined in Unicode Standard Annex #29.
+type graphemeBreak int
+
+const (
+	gbOther graphemeBreak = iota
+	gbCR
+	gbLF
+	gbControl
+	gbExtend
+	gbZWJ
+	gbRegionalIndicator
+	gbPrepend
+	gbSpacingMark
+	gbL
+	gbV
+	gbT
+	gbLV
+	gbLVT
+	gbExtendedPictographic
+)
+
+// graphemeCount returns the number of extended grapheme clusters in s.
+func graphemeCount(s string) int {
+	count := 0
+	var prev graphemeBreak
+	// riCount is the number of consecutive regional indicators before
+	// the current rune.
+	riCount := 0
+	// emojiZWJ is true if the previous runes are an extended
+	// pictographic followed by extends then a ZWJ.
+	emojiZWJ := false
+	inEmoji := false
+	// conjunct is the state of the Indic conjunct of GB9c before
+	// the current rune.
+	conjunct := conjunctNone
+	for i, r := range s {
+		cur := graphemeBreakOf(r)
+		consonant := unicode.Is(indicConsonants, r)
+		if i == 0 || graphemeBoundary(prev, cur, riCount, emojiZWJ,
+			consonant && conjunct == conjunctLinker) {
+			count++
+		}
+
+		switch {
+		case consonant:
+			conjunct = conjunctConsonant
+		case conjunct == conjunctNone:
+		case isIndicLinker(r):
+			conjunct = conjunctLinker
+		case cur != gbExtend && cur != gbZWJ:
+			conjunct = conjunctNone
+		}
+
+		switch cur {
+		case gbRegionalIndicator:
+			riCount++
+		default:
+			riCount = 0
+		}
+		switch {
+		case cur == gbExtendedPictographic:
+			inEmoji, emojiZWJ = true, false
+		case inEmoji && cur == gbExtend:
+			emojiZWJ = false
+		case inEmoji && cur == gbZWJ:
+			emojiZWJ = true
+		default:
+			inEmoji, emojiZWJ = false, false
+		}
+		prev = cur
+	}
+	return count
+}
+
+// The states of an Indic conjunct, i.e., a consonant followed by
+// extends and linkers, for GB9c.
+const (
+	conjunctNone = iota
+	// conjunctConsonant is after a consonant and optional extends.
+	conjunctConsonant
+	// conjunctLinker is after a consonant, then extends and at least
+	// one linker.
+	conjunctLinker
+)
+
+// graphemeBoundary returns true if there's a grapheme cluster boundary
+// between runes with the properties prev and cur. conjunct is true if
+// cur is a consonant which continues an Indic conjunct.
+func graphemeBoundary(prev, cur graphemeBreak, riCount int, emojiZWJ, conjunct bool) bool {
+	switch {
+	case prev == gbCR && cur == gbLF: // GB3
+		return false
+	case prev == gbCR || prev == gbLF || prev == gbControl: // GB4
+		return true
+	case cur == gbCR || cur == gbLF || cur == gbControl: // GB5
+		return true
+	case prev == gbL && (cur == gbL || cur == gbV || cur == gbLV || cur == gbLVT): // GB6
+		return false
+	case (prev == gbLV || prev == gbV) && (cur == gbV || cur == gbT): // GB7
+		return false
+	case (prev == gbLVT || prev == gbT) && cur == gbT: // GB8
+		return false
+	case cur == gbExtend || cur == gbZWJ: // GB9
+		return false
+	case cur == gbSpacingMark: // GB9a
+		return false
+	case prev == gbPrepend: // GB9b
+		return false
+	case conjunct: // GB9c
+		return false
+	case emojiZWJ && cur == gbExtendedPictographic: // GB11
+		return false
+	case cur == gbRegionalIndicator && riCount%2 == 1: // GB12, GB13
+		return false
+	}
+	return true // GB999
+}
+
+func graphemeBreakOf(r rune) graphemeBreak {
+	switch {
+	case r == '\r':
+		return gbCR
+	case r == '\n':
+		return gbLF
+	case r == 0x200D:
+		return gbZWJ
+	case r == 0x200C:
+		return gbExtend
+	case r >= 0x1F1E6 && r <= 0x1F1FF:
+		return gbRegionalIndicator
+	case r >= 0x1F3FB && r <= 0x1F3FF: // emoji modifiers
+		return gbExtend
+	case r >= 0xE0020 && r <= 0xE007F: // tags
+		return gbExtend
+	case r >= 0x1100 && r <= 0x115F, r >= 0xA960 && r <= 0xA97C:
+		return gbL
+	case r >= 0x1160 && r <= 0x11A7, r >= 0xD7B0 && r <= 0xD7C6:
+		return gbV
+	case r >= 0x11A8 && r <= 0x11FF, r >= 0xD7CB && r <= 0xD7FB:
+		return gbT
+	case r >= 0xAC00 && r <= 0xD7A3:
+		if (r-0xAC00)%28 == 0 {
+			return gbLV
+		}
+		return gbLVT
+	case isPrepend(r):
+		return gbPrepend
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Other_Grapheme_Extend):
+		return gbExtend
+	case unicode.In(r, unicode.Cc, unicode.Cf, unicode.Zl, unicode.Zp):
+		return gbControl
+	case unicode.Is(unicode.Mc, r), r == 0x0E33, r == 0x0EB3:
+		return gbSpacingMark
+	case isExtendedPictographic(r):
+		return gbExtendedPictographic
+	}
+	return gbOther
+}
+
+// isPrepend covers the prepended concatenation marks, which are
+// the most common Prepend runes.
+func isPrepend(r rune) bool {
+	switch {
+	case r >= 0x0600 && r <= 0x0605, r == 0x06DD, r == 0x070F, r == 0x0890, r == 0x0891,
+		r == 0x08E2, r == 0x110BD, r == 0x110CD:
+		return true
+	}
+	return false
+}
+
+// isIndicLinker returns true if r has the Indic_Conjunct_Break property
+// Linker, i.e., it's the virama of one of the scripts in
+// indicConsonants.
+func isIndicLinker(r rune) bool {
+	switch r {
+	case 0x094D, 0x09CD, 0x0ACD, 0x0B4D, 0x0C4D, 0x0D4D:
+		return true
+	}
+	return false
+}
+
+// indicConsonants contains the runes with the Indic_Conjunct_Break
+// property Consonant, as of Unicode 15.1, which is not in package
+// unicode. They are the consonants of Devanagari, Bengali, Gujarati,
+// Oriya, Telugu and Malayalam.
+var indicConsonants = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x0915, Hi: 0x0939, Stride: 1},
+		{Lo: 0x0958, Hi: 0x095F, Stride: 1},
+		{Lo: 0x0978, Hi: 0x097F, Stride: 1},
+		{Lo: 0x0995, Hi: 0x09A8, Stride: 1},
+		{Lo: 0x09AA, Hi: 0x09B0, Stride: 1},
+		{Lo: 0x09B2, Hi: 0x09B6, Stride: 4},
+		{Lo: 0x09B7, Hi: 0x09B9, Stride: 1},
+		{Lo: 0x09DC, Hi: 0x09DD, Stride: 1},
+		{Lo: 0x09DF, Hi: 0x09DF, Stride: 1},
+		{Lo: 0x09F0, Hi: 0x09F1, Stride: 1},
+		{Lo: 0x0A95, Hi: 0x0AA8, Stride: 1},
+		{Lo: 0x0AAA, Hi: 0x0AB0, Stride: 1},
+		{Lo: 0x0AB2, Hi: 0x0AB3, Stride: 1},
+		{Lo: 0x0AB5, Hi: 0x0AB9, Stride: 1},
+		{Lo: 0x0AF9, Hi: 0x0AF9, Stride: 1},
+		{Lo: 0x0B15, Hi: 0x0B28, Stride: 1},
+		{Lo: 0x0B2A, Hi: 0x0B30, Stride: 1},
+		{Lo: 0x0B32, Hi: 0x0B33, Stride: 1},
+		{Lo: 0x0B35, Hi: 0x0B39, Stride: 1},
+		{Lo: 0x0B5C, Hi: 0x0B5D, Stride: 1},
+		{Lo: 0x0B5F, Hi: 0x0B5F, Stride: 1},
+		{Lo: 0x0B71, Hi: 0x0B71, Stride: 1},
+		{Lo: 0x0C15, Hi: 0x0C28, Stride: 1},
+		{Lo: 0x0C2A, Hi: 0x0C39, Stride: 1},
+		{Lo: 0x0C58, Hi: 0x0C5A, Stride: 1},
+		{Lo: 0x0D15, Hi: 0x0D3A, Stride: 1},
+	},
+}
+
+// isExtendedPictographic approximates the Extended_Pictographic
+// property, which is not in package unicode, with the blocks of emoji
+// and the pictographic symbols outside of them.
+func isExtendedPictographic(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF, r >= 0x1FC00 && r <= 0x1FFFD:
+		return true
+	case r >= 0x2600 && r <= 0x27BF, r >= 0x2300 && r <= 0x23FF,
+		r >= 0x2B00 && r <= 0x2BFF, r >= 0x2194 && r <= 0x21AA:
+		return true
+	case r == 0x00A9, r == 0x00AE, r == 0x203C, r == 0x2049, r == 0x2122,
+		r == 0x2139, r == 0x3030, r == 0x303D, r == 0x3297, r == 0x3299:
+		return true
+	}
+	return false
+}
@@ -6,11 +6,15 @@ import (
 	"github.com/rez-go/constraints"
 )
 
-// TODO: generic slices and others
+// lenable is the type set of the values whose length could be
+// constrained with Length and its siblings. See SliceLength and
+// MapLength for slices and maps.
 type lenable interface {
 	~string | []byte
 }
 
+func lenableLength[ValueT lenable](v ValueT) int { return len(v) }
+
 // Length returns a Constraint that will declare that a value
 // as valid if its length is exactly as specified.
 //
@@ -19,7 +23,7 @@ func Length[ValueT lenable](specifiedLength int) constraints.Constraint[ValueT]
 	if specifiedLength < 0 {
 		panic("specifiedLength must be zero or a positive integer")
 	}
-	return &lengthConstraint[ValueT]{min: specifiedLength, max: specifiedLength}
+	return &lengthConstraint[ValueT]{min: specifiedLength, max: specifiedLength, unit: LengthUnitBytes, length: lenableLength[ValueT]}
 }
 
 // Length returns a Constraint that will declare that a value
@@ -30,7 +34,7 @@ func MaxLength[ValueT lenable](maxLength int) constraints.Constraint[ValueT] {
 	if maxLength < 0 {
 		panic("maxLength must be zero or a positive integer")
 	}
-	return &lengthConstraint[ValueT]{min: -1, max: maxLength}
+	return &lengthConstraint[ValueT]{min: -1, max: maxLength, unit: LengthUnitBytes, length: lenableLength[ValueT]}
 }
 
 // Length returns a Constraint that will declare that a value
@@ -41,47 +45,173 @@ func MinLength[ValueT lenable](minLength int) constraints.Constraint[ValueT] {
 	if minLength < 0 {
 		panic("minLength must be zero or a positive integer")
 	}
-	return &lengthConstraint[ValueT]{min: minLength, max: -1}
+	return &lengthConstraint[ValueT]{min: minLength, max: -1, unit: LengthUnitBytes, length: lenableLength[ValueT]}
 }
 
+// LengthRange returns a Constraint that will declare that a value
+// as valid if its length is between min and max, inclusive.
+//
+// API status: experimental
 func LengthRange[ValueT lenable](min int, max int) constraints.Constraint[ValueT] {
-	return &lengthConstraint[ValueT]{min: min, max: max}
+	return &lengthConstraint[ValueT]{min: min, max: max, unit: LengthUnitBytes, length: lenableLength[ValueT]}
+}
+
+// Kinds of the length constraints.
+const (
+	KindLength      constraints.Kind = "length"
+	KindLengthMin   constraints.Kind = "length.min"
+	KindLengthMax   constraints.Kind = "length.max"
+	KindLengthRange constraints.Kind = "length.range"
+)
+
+// A LengthUnit is what the length constraints count.
+//
+// API status: experimental
+type LengthUnit string
+
+// Units of the length constraints.
+const (
+	// LengthUnitBytes counts the bytes of strings, e.g., the length of
+	// "日本" is 6. It's the unit of Length and its siblings.
+	LengthUnitBytes LengthUnit = "bytes"
+	// LengthUnitRunes counts the Unicode code points, e.g., the length
+	// of "日本" is 2. It's the unit of the length in JSON Schema.
+	LengthUnitRunes LengthUnit = "runes"
+	// LengthUnitGraphemes counts the extended grapheme clusters, i.e.,
+	// the user-perceived characters, e.g., the length of "e\u0301" is 1.
+	LengthUnitGraphemes LengthUnit = "graphemes"
+	// LengthUnitElements counts the elements of slices and the entries
+	// of maps.
+	LengthUnitElements LengthUnit = "elements"
+)
+
+// LengthBoundedConstraint is implemented by constraints which limit
+// the length of values.
+type LengthBoundedConstraint interface {
+	constraints.ConstraintBase
+
+	// LengthBounds returns the minimum and the maximum length. A negative
+	// value means that the length is unbounded on that side.
+	LengthBounds() (min, max int)
+}
+
+// LengthUnitConstraint is implemented by the length constraints to
+// tell what their lengths count. Exporters should check the unit
+// before mapping the bounds, e.g., only the lengths in runes are
+// equivalent to minLength and maxLength of JSON Schema.
+//
+// API status: experimental
+type LengthUnitConstraint interface {
+	LengthBoundedConstraint
+
+	// LengthUnit returns the unit of the length.
+	LengthUnit() LengthUnit
 }
 
 // lengthConstraint defines exact length Constraint.
-type lengthConstraint[ValueT lenable] struct {
-	min int
-	max int
+type lengthConstraint[ValueT any] struct {
+	min    int
+	max    int
+	unit   LengthUnit
+	length func(v ValueT) int
 }
 
 var (
-	_ StringConstraint = lengthConstraint[string]{}
+	_ StringConstraint              = lengthConstraint[string]{}
+	_ LengthBoundedConstraint       = lengthConstraint[string]{}
+	_ LengthUnitConstraint          = lengthConstraint[string]{}
+	_ constraints.KindedConstraint  = lengthConstraint[string]{}
+	_ constraints.MessageConstraint = lengthConstraint[string]{}
+	_ constraints.CostedConstraint  = lengthConstraint[string]{}
 )
 
 // ConstraintDescription conforms constraints.Constraint interface.
 func (c lengthConstraint[ValueT]) ConstraintDescription() string {
+	var unit string
+	switch c.unit {
+	case LengthUnitRunes:
+		unit = "rune "
+	case LengthUnitGraphemes:
+		unit = "grapheme "
+	}
 	if c.min == c.max {
-		return fmt.Sprintf("length %d", c.min)
+		return fmt.Sprintf("%slength %d", unit, c.min)
 	}
 	if c.min == -1 {
-		return fmt.Sprintf("max length %d", c.max)
+		return fmt.Sprintf("max %slength %d", unit, c.max)
 	}
 	if c.max == -1 {
-		return fmt.Sprintf("min length %d", c.min)
+		return fmt.Sprintf("min %slength %d", unit, c.min)
 	}
-	return fmt.Sprintf("length betwen %d and %d", c.min, c.max)
+	return fmt.Sprintf("%slength between %d and %d", unit, c.min, c.max)
+}
+
+// ConstraintKind conforms constraints.KindedConstraint interface.
+func (c lengthConstraint[ValueT]) ConstraintKind() constraints.Kind {
+	kinds := [4]constraints.Kind{KindLength, KindLengthMin, KindLengthMax, KindLengthRange}
+	switch c.unit {
+	case LengthUnitRunes:
+		kinds = [4]constraints.Kind{KindRuneLength, KindRuneLengthMin, KindRuneLengthMax, KindRuneLengthRange}
+	case LengthUnitGraphemes:
+		kinds = [4]constraints.Kind{KindGraphemeLength, KindGraphemeLengthMin, KindGraphemeLengthMax, KindGraphemeLengthRange}
+	}
+	if c.min == c.max {
+		return kinds[0]
+	}
+	if c.min == -1 {
+		return kinds[2]
+	}
+	if c.max == -1 {
+		return kinds[1]
+	}
+	return kinds[3]
+}
+
+// ConstraintMessage conforms constraints.MessageConstraint interface.
+func (c lengthConstraint[ValueT]) ConstraintMessage() constraints.Message {
+	kind := c.ConstraintKind()
+	var args map[string]any
+	switch {
+	case c.min == c.max:
+		args = map[string]any{"length": c.min}
+	case c.max == -1:
+		args = map[string]any{"min": c.min}
+	case c.min == -1:
+		args = map[string]any{"max": c.max}
+	default:
+		args = map[string]any{"min": c.min, "max": c.max}
+	}
+	// The unit lets templates name what's counted, e.g., "6 bytes".
+	args["unit"] = string(c.unit)
+	return constraints.Message{ID: string(kind), Args: args, Text: c.ConstraintDescription()}
+}
+
+// LengthBounds conforms LengthBoundedConstraint interface.
+func (c lengthConstraint[ValueT]) LengthBounds() (min, max int) {
+	return c.min, c.max
+}
+
+// LengthUnit conforms LengthUnitConstraint interface.
+func (c lengthConstraint[ValueT]) LengthUnit() LengthUnit {
+	return c.unit
+}
+
+// ConstraintCost conforms constraints.CostedConstraint interface.
+func (c lengthConstraint[ValueT]) ConstraintCost() constraints.Cost {
+	return constraints.CostCheap
 }
 
 // IsValid conforms Constraint interface.
 func (c lengthConstraint[ValueT]) IsValid(v ValueT) bool {
+	n := c.length(v)
 	if c.min == c.max {
-		return len(v) == c.min
+		return n == c.min
 	}
 	if c.min == -1 {
-		return len(v) <= c.max
+		return n <= c.max
 	}
 	if c.max == -1 {
-		return len(v) >= c.min
+		return n >= c.min
 	}
-	return len(v) >= c.min && len(v) <= c.max
+	return n >= c.min && n <= c.max
 }
@@ -0,0 +1,36 @@
+package stdtypes
+
+import (
+	"testing"
+
+	"github.com/rez-go/constraints"
+)
+
+func TestLengthIntrospection(t *testing.T) {
+	cases := []struct {
+		constraint StringConstraint
+		kind       constraints.Kind
+		min        int
+		max        int
+	}{
+		{StringLength(5), KindLength, 5, 5},
+		{StringMinLength(6), KindLengthMin, 6, -1},
+		{StringMaxLength(32), KindLengthMax, -1, 32},
+		{StringLengthRange(6, 32), KindLengthRange, 6, 32},
+	}
+	for _, c := range cases {
+		assertEq(t, c.kind, constraints.KindOf(c.constraint))
+		min, max := c.constraint.(LengthBoundedConstraint).LengthBounds()
+		assertEq(t, c.min, min)
+		assertEq(t, c.max, max)
+	}
+}
+
+func TestPrefixSuffixIntrospection(t *testing.T) {
+	prefix := StringPrefix("foo")
+	assertEq(t, KindStringPrefix, constraints.KindOf(prefix))
+	assertEq(t, "foo", prefix.(constraints.OperandConstraint[string]).Operand())
+	suffix := StringSuffix("_")
+	assertEq(t, KindStringSuffix, constraints.KindOf(suffix))
+	assertEq(t, "_", suffix.(constraints.OperandConstraint[string]).Operand())
+}
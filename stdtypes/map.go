@@ -0,0 +1,240 @@
+package stdtypes
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/rez-go/constraints"
+)
+
+// Kinds of the map constraints. The length constraints of maps have
+// the same kinds as those of strings, e.g., KindLengthMin.
+const (
+	KindMapKeys   constraints.Kind = "map.keys"
+	KindMapValues constraints.Kind = "map.values"
+)
+
+// MapLength returns a Constraint that will declare a map as valid if
+// its number of entries is exactly as specified.
+//
+// API status: experimental
+func MapLength[MapT ~map[K]V, K comparable, V any](specifiedLength int) constraints.Constraint[MapT] {
+	if specifiedLength < 0 {
+		panic("specifiedLength must be zero or a positive integer")
+	}
+	return &lengthConstraint[MapT]{min: specifiedLength, max: specifiedLength, unit: LengthUnitElements, length: mapLength[MapT]}
+}
+
+// MapMaxLength returns a Constraint that will declare a map as valid
+// if its number of entries is at most maxLength.
+//
+// API status: experimental
+func MapMaxLength[MapT ~map[K]V, K comparable, V any](maxLength int) constraints.Constraint[MapT] {
+	if maxLength < 0 {
+		panic("maxLength must be zero or a positive integer")
+	}
+	return &lengthConstraint[MapT]{min: -1, max: maxLength, unit: LengthUnitElements, length: mapLength[MapT]}
+}
+
+// MapMinLength returns a Constraint that will declare a map as valid
+// if its number of entries is at least minLength.
+//
+// API status: experimental
+func MapMinLength[MapT ~map[K]V, K comparable, V any](minLength int) constraints.Constraint[MapT] {
+	if minLength < 0 {
+		panic("minLength must be zero or a positive integer")
+	}
+	return &lengthConstraint[MapT]{min: minLength, max: -1, unit: LengthUnitElements, length: mapLength[MapT]}
+}
+
+// MapLengthRange returns a Constraint that will declare a map as valid
+// if its number of entries is between min and max, inclusive.
+//
+// API status: experimental
+func MapLengthRange[MapT ~map[K]V, K comparable, V any](min, max int) constraints.Constraint[MapT] {
+	if min < 0 {
+		panic("min must be zero or a positive integer")
+	}
+	if max < 0 {
+		panic("max must be zero or a positive integer")
+	}
+	return &lengthConstraint[MapT]{min: min, max: max, unit: LengthUnitElements, length: mapLength[MapT]}
+}
+
+func mapLength[MapT ~map[K]V, K comparable, V any](v MapT) int { return len(v) }
+
+// Keys creates a Constraint which declares a map as valid if all of
+// its keys satisfy constraint c.
+//
+// Its FieldErrors method reports each invalid key at the key, e.g.,
+// `labels["Bad Key"]` for a map in the field "labels" of a Struct.
+//
+// API status: experimental
+func Keys[MapT ~map[K]V, K comparable, V any](c constraints.Constraint[K]) constraints.Constraint[MapT] {
+	return &mapKeysConstraint[MapT, K, V]{c: c}
+}
+
+type mapKeysConstraint[MapT ~map[K]V, K comparable, V any] struct {
+	c constraints.Constraint[K]
+}
+
+var (
+	_ ElementsConstraint            = &mapKeysConstraint[map[string]int, string, int]{}
+	_ constraints.KindedConstraint  = &mapKeysConstraint[map[string]int, string, int]{}
+	_ constraints.MessageConstraint = &mapKeysConstraint[map[string]int, string, int]{}
+)
+
+// ConstraintDescription conforms constraints.Constraint interface.
+func (c *mapKeysConstraint[MapT, K, V]) ConstraintDescription() string {
+	return "keys: " + c.c.ConstraintDescription()
+}
+
+// ConstraintKind conforms constraints.KindedConstraint interface.
+func (c *mapKeysConstraint[MapT, K, V]) ConstraintKind() constraints.Kind {
+	return KindMapKeys
+}
+
+// ConstraintMessage conforms constraints.MessageConstraint interface.
+func (c *mapKeysConstraint[MapT, K, V]) ConstraintMessage() constraints.Message {
+	return constraints.Message{
+		ID:   string(KindMapKeys),
+		Args: map[string]any{"constraint": constraints.MessageOf(c.c)},
+		Text: c.ConstraintDescription(),
+	}
+}
+
+// ElementConstraint conforms ElementsConstraint interface. It returns
+// the constraint of the keys.
+func (c *mapKeysConstraint[MapT, K, V]) ElementConstraint() constraints.ConstraintBase {
+	return c.c
+}
+
+// IsValid conforms constraints.Constraint interface.
+func (c *mapKeysConstraint[MapT, K, V]) IsValid(v MapT) bool {
+	for k := range v {
+		if !c.c.IsValid(k) {
+			return false
+		}
+	}
+	return true
+}
+
+// FieldErrors reports each invalid key at the key. The errors are
+// ordered by the keys.
+func (c *mapKeysConstraint[MapT, K, V]) FieldErrors(v MapT) constraints.ErrorList {
+	var errs constraints.ErrorList
+	for _, k := range sortedKeys(v) {
+		errs.Add(constraints.FieldPath{}.Key(fmt.Sprint(k)), constraints.ValidOrError(k, c.c))
+	}
+	return errs
+}
+
+// Values creates a Constraint which declares a map as valid if all of
+// its values satisfy constraint c.
+//
+// Its FieldErrors method reports each invalid value at its key.
+//
+// API status: experimental
+func Values[MapT ~map[K]V, K comparable, V any](c constraints.Constraint[V]) constraints.Constraint[MapT] {
+	return &mapValuesConstraint[MapT, K, V]{c: c}
+}
+
+type mapValuesConstraint[MapT ~map[K]V, K comparable, V any] struct {
+	c constraints.Constraint[V]
+}
+
+var (
+	_ ElementsConstraint            = &mapValuesConstraint[map[string]int, string, int]{}
+	_ constraints.KindedConstraint  = &mapValuesConstraint[map[string]int, string, int]{}
+	_ constraints.MessageConstraint = &mapValuesConstraint[map[string]int, string, int]{}
+)
+
+// ConstraintDescription conforms constraints.Constraint interface.
+func (c *mapValuesConstraint[MapT, K, V]) ConstraintDescription() string {
+	return "values: " + c.c.ConstraintDescription()
+}
+
+// ConstraintKind conforms constraints.KindedConstraint interface.
+func (c *mapValuesConstraint[MapT, K, V]) ConstraintKind() constraints.Kind {
+	return KindMapValues
+}
+
+// ConstraintMessage conforms constraints.MessageConstraint interface.
+func (c *mapValuesConstraint[MapT, K, V]) ConstraintMessage() constraints.Message {
+	return constraints.Message{
+		ID:   string(KindMapValues),
+		Args: map[string]any{"constraint": constraints.MessageOf(c.c)},
+		Text: c.ConstraintDescription(),
+	}
+}
+
+// ElementConstraint conforms ElementsConstraint interface. It returns
+// the constraint of the values.
+func (c *mapValuesConstraint[MapT, K, V]) ElementConstraint() constraints.ConstraintBase {
+	return c.c
+}
+
+// IsValid conforms constraints.Constraint interface.
+func (c *mapValuesConstraint[MapT, K, V]) IsValid(v MapT) bool {
+	for _, e := range v {
+		if !c.c.IsValid(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// FieldErrors reports each invalid value at its key. The errors are
+// ordered by the keys.
+func (c *mapValuesConstraint[MapT, K, V]) FieldErrors(v MapT) constraints.ErrorList {
+	var errs constraints.ErrorList
+	for _, k := range sortedKeys(v) {
+		errs.Add(constraints.FieldPath{}.Key(fmt.Sprint(k)), elementError(v[k], c.c))
+	}
+	return errs
+}
+
+// sortedKeys returns the keys of m in order so that the errors are
+// reported deterministically. Numbers are ordered numerically, e.g., 2
+// before 10, and other keys by their string representations.
+func sortedKeys[MapT ~map[K]V, K comparable, V any](m MapT) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keyLess(keys[i], keys[j])
+	})
+	return keys
+}
+
+func keyLess(a, b any) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.IsValid() && vb.IsValid() && numberKind(va.Kind()) == numberKind(vb.Kind()) {
+		switch numberKind(va.Kind()) {
+		case reflect.Int:
+			return va.Int() < vb.Int()
+		case reflect.Uint:
+			return va.Uint() < vb.Uint()
+		case reflect.Float64:
+			return va.Float() < vb.Float()
+		}
+	}
+	return fmt.Sprint(a) < fmt.Sprint(b)
+}
+
+// numberKind returns reflect.Int, reflect.Uint or reflect.Float64 for
+// the kinds of signed integers, unsigned integers and floating-point
+// numbers respectively, or reflect.Invalid for the other kinds.
+func numberKind(k reflect.Kind) reflect.Kind {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflect.Int
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return reflect.Uint
+	case reflect.Float32, reflect.Float64:
+		return reflect.Float64
+	}
+	return reflect.Invalid
+}
@@ -0,0 +1,45 @@
+package stdtypes
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/rez-go/constraints"
+)
+
+func TestMapLength(t *testing.T) {
+	c := MapLengthRange[map[string]int](1, 2)
+	assertEq(t, KindLengthRange, constraints.KindOf(c))
+	assertEq(t, false, c.IsValid(nil))
+	assertEq(t, true, c.IsValid(map[string]int{"a": 1}))
+	assertEq(t, false, c.IsValid(map[string]int{"a": 1, "b": 2, "c": 3}))
+	assertEq(t, "min length 1", MapMinLength[map[string]int](1).ConstraintDescription())
+}
+
+func TestKeysValues(t *testing.T) {
+	keys := Keys[map[string]int](StringPattern(regexp.MustCompile(`^[a-z]+$`)))
+	values := Values[map[string]int, string, int](constraints.Min(0))
+	assertEq(t, `keys: match pattern "^[a-z]+$"`, keys.ConstraintDescription())
+	assertEq(t, "values: min 0", values.ConstraintDescription())
+	assertEq(t, KindMapValues, constraints.KindOf(values))
+
+	labels := map[string]int{"env": 1, "Bad Key": 2, "tier": -1, "zone": -2}
+	assertEq(t, false, keys.IsValid(labels))
+	assertEq(t, false, values.IsValid(labels))
+	assertEq(t, true, values.IsValid(map[string]int{"env": 1}))
+
+	var errs constraints.ErrorList
+	constraints.ValidateField[map[string]int](&errs, constraints.Path("labels"), labels, keys)
+	constraints.ValidateField[map[string]int](&errs, constraints.Path("labels"), labels, values)
+	assertEq(t, []string{`labels["Bad Key"]`, `labels["tier"]`, `labels["zone"]`},
+		errorPaths(errs))
+	assertEq(t, `/labels/Bad Key`, errs[0].Path.JSONPointer())
+}
+
+func TestKeysNumericOrder(t *testing.T) {
+	values := Values[map[int]string, int, string](NonEmptyString)
+	var errs constraints.ErrorList
+	constraints.ValidateField[map[int]string](&errs, constraints.Path("pages"),
+		map[int]string{10: "", 2: "", -1: "", 3: "ok"}, values)
+	assertEq(t, []string{`pages["-1"]`, `pages["2"]`, `pages["10"]`}, errorPaths(errs))
+}
@@ -32,7 +32,9 @@ var _ Int8Constraint = Int8Positive
 // Positive creates a constraint that will declare an instance as valid
 // if its value is positive.
 func Positive[ValueT numeric]() NumericConstraint[ValueT] {
-	return constraints.Func("positive", PositiveCheck[ValueT])
+	return constraints.Func("positive", PositiveCheck[ValueT],
+		constraints.WithMessage("positive", nil),
+		constraints.WithCost(constraints.CostCheap))
 }
 
 func PositiveCheck[ValueT numeric](v ValueT) bool { return v > 0 }
@@ -48,7 +50,9 @@ var (
 // Negative creates a constraint that will declare an instance as valid
 // if its value is negative.
 func Negative[ValueT numeric]() NumericConstraint[ValueT] {
-	return constraints.Func("negative", NegativeCheck[ValueT])
+	return constraints.Func("negative", NegativeCheck[ValueT],
+		constraints.WithMessage("negative", nil),
+		constraints.WithCost(constraints.CostCheap))
 }
 
 func NegativeCheck[ValueT numeric](v ValueT) bool { return v < 0 }
@@ -64,7 +68,9 @@ var (
 // Even creates a constraint that will declare an instance as valid
 // if its value is even.
 func Even[ValueT typecons.Integer]() NumericConstraint[ValueT] {
-	return constraints.Func("even", EvenCheck[ValueT])
+	return constraints.Func("even", EvenCheck[ValueT],
+		constraints.WithMessage("even", nil),
+		constraints.WithCost(constraints.CostCheap))
 }
 
 func EvenCheck[ValueT typecons.Integer](v ValueT) bool { return (v & 1) == 0 }
@@ -72,7 +78,9 @@ func EvenCheck[ValueT typecons.Integer](v ValueT) bool { return (v & 1) == 0 }
 // Odd creates a constraint that will declare an instance as valid
 // if its value is odd.
 func Odd[ValueT typecons.Integer]() NumericConstraint[ValueT] {
-	return constraints.Func("odd", OddCheck[ValueT])
+	return constraints.Func("odd", OddCheck[ValueT],
+		constraints.WithMessage("odd", nil),
+		constraints.WithCost(constraints.CostCheap))
 }
 
 func OddCheck[ValueT typecons.Integer](v ValueT) bool { return (v & 1) == 1 }
@@ -80,7 +88,9 @@ func OddCheck[ValueT typecons.Integer](v ValueT) bool { return (v & 1) == 1 }
 // PowerOfTwo creates a constraint that will declare an instance as valid
 // if its value is power of two.
 func PowerOfTwo[ValueT typecons.Integer]() NumericConstraint[ValueT] {
-	return constraints.Func("power of two", PowerOfTwoCheck[ValueT])
+	return constraints.Func("power of two", PowerOfTwoCheck[ValueT],
+		constraints.WithMessage("power_of_two", nil),
+		constraints.WithCost(constraints.CostCheap))
 }
 
 func PowerOfTwoCheck[ValueT typecons.Integer](v ValueT) bool {
@@ -0,0 +1,223 @@
+package stdtypes
+
+import (
+	"fmt"
+	"math"
+	"unicode/utf8"
+
+	"github.com/rez-go/constraints"
+)
+
+// Kinds of the positional string constraints.
+const (
+	KindStringRuneAt       constraints.Kind = "string.rune_at"
+	KindStringFirstRunes   constraints.Kind = "string.first_runes"
+	KindStringLastRunes    constraints.Kind = "string.last_runes"
+	KindStringRunesBetween constraints.Kind = "string.runes_between"
+)
+
+// EndOfString could be used as the end of a RuneSpan to make the span
+// extend to the end of strings.
+const EndOfString = math.MaxInt
+
+// A RuneSpan specifies the positions of runes in strings, from Start,
+// inclusive, to End, exclusive. The positions are in runes, not bytes.
+// Negative positions are counted from the end of strings, e.g., -1 is
+// the position of the last rune.
+//
+// Like slicing in Go, a span which is outside of a string, partly or
+// wholly, covers only the runes which are in the string.
+//
+// API status: experimental
+type RuneSpan struct {
+	Start int
+	End   int
+}
+
+// resolve returns the span in v with the negative positions counted
+// from the end of v. The results might still be negative or beyond
+// the end of v.
+func (s RuneSpan) resolve(v string) (start, end int) {
+	start, end = s.Start, s.End
+	if start < 0 || end < 0 {
+		n := utf8.RuneCountInString(v)
+		if start < 0 {
+			start += n
+		}
+		if end < 0 {
+			end += n
+		}
+	}
+	return start, end
+}
+
+// RuneSpanConstraint is implemented by the constraints which validate
+// the runes at some positions of strings, e.g., StringRuneAt.
+//
+// API status: experimental
+type RuneSpanConstraint interface {
+	StringConstraint
+
+	// RuneSpan returns the positions of the runes which are validated.
+	// For StringRuneAt, Start is the index.
+	RuneSpan() RuneSpan
+
+	// RuneConstraint returns the constraint of the runes.
+	RuneConstraint() RuneConstraint
+}
+
+// StringRuneAt creates a Constraint which declares a string as valid if
+// it has a rune at index, in runes, and the rune satisfies constraint c.
+// A negative index is counted from the end of strings, e.g., -1 is
+// the last rune.
+//
+// API status: experimental
+func StringRuneAt(index int, c RuneConstraint) RuneSpanConstraint {
+	span := RuneSpan{Start: index, End: index + 1}
+	if index == -1 {
+		span.End = EndOfString
+	}
+	return &runeSpanConstraint{kind: KindStringRuneAt, span: span, c: c}
+}
+
+// StringFirstRunes creates a Constraint which declares a string as valid
+// if each of its first n runes satisfies constraint c. The runes of
+// strings shorter than n are all validated; combine it with
+// a length constraint to require n runes.
+//
+// API status: experimental
+func StringFirstRunes(n int, c RuneConstraint) RuneSpanConstraint {
+	if n < 0 {
+		panic("n must be zero or a positive integer")
+	}
+	return &runeSpanConstraint{kind: KindStringFirstRunes, span: RuneSpan{Start: 0, End: n}, c: c}
+}
+
+// StringLastRunes creates a Constraint which declares a string as valid
+// if each of its last n runes satisfies constraint c. See
+// StringFirstRunes for strings shorter than n.
+//
+// API status: experimental
+func StringLastRunes(n int, c RuneConstraint) RuneSpanConstraint {
+	if n < 0 {
+		panic("n must be zero or a positive integer")
+	}
+	span := RuneSpan{Start: -n, End: EndOfString}
+	if n == 0 {
+		span = RuneSpan{}
+	}
+	return &runeSpanConstraint{kind: KindStringLastRunes, span: span, c: c}
+}
+
+// StringRunesBetween creates a Constraint which declares a string as
+// valid if each of its runes from position start, inclusive, to end,
+// exclusive, satisfies constraint c. See RuneSpan for the positions.
+//
+// API status: experimental
+func StringRunesBetween(start, end int, c RuneConstraint) RuneSpanConstraint {
+	return &runeSpanConstraint{kind: KindStringRunesBetween, span: RuneSpan{Start: start, End: end}, c: c}
+}
+
+type runeSpanConstraint struct {
+	kind constraints.Kind
+	span RuneSpan
+	c    RuneConstraint
+}
+
+var (
+	_ RuneSpanConstraint            = &runeSpanConstraint{}
+	_ constraints.KindedConstraint  = &runeSpanConstraint{}
+	_ constraints.MessageConstraint = &runeSpanConstraint{}
+)
+
+// ConstraintDescription conforms constraints.Constraint interface.
+func (c *runeSpanConstraint) ConstraintDescription() string {
+	desc := c.c.ConstraintDescription()
+	switch c.kind {
+	case KindStringRuneAt:
+		return fmt.Sprintf("rune at index %d: %s", c.span.Start, desc)
+	case KindStringFirstRunes:
+		return fmt.Sprintf("first %d runes: %s", c.span.End, desc)
+	case KindStringLastRunes:
+		return fmt.Sprintf("last %d runes: %s", -c.span.Start, desc)
+	}
+	if c.span.End == EndOfString {
+		return fmt.Sprintf("runes [%d:]: %s", c.span.Start, desc)
+	}
+	return fmt.Sprintf("runes [%d:%d]: %s", c.span.Start, c.span.End, desc)
+}
+
+// ConstraintKind conforms constraints.KindedConstraint interface.
+func (c *runeSpanConstraint) ConstraintKind() constraints.Kind {
+	return c.kind
+}
+
+// ConstraintMessage conforms constraints.MessageConstraint interface.
+func (c *runeSpanConstraint) ConstraintMessage() constraints.Message {
+	id := string(c.kind)
+	args := map[string]any{"constraint": constraints.MessageOf(c.c)}
+	switch c.kind {
+	case KindStringRuneAt:
+		args["index"] = c.span.Start
+	case KindStringFirstRunes:
+		args["count"] = c.span.End
+	case KindStringLastRunes:
+		args["count"] = -c.span.Start
+	default:
+		args["start"] = c.span.Start
+		if c.span.End == EndOfString {
+			id = "string.runes_from"
+		} else {
+			args["end"] = c.span.End
+		}
+	}
+	return constraints.Message{ID: id, Args: args, Text: c.ConstraintDescription()}
+}
+
+// RuneSpan conforms RuneSpanConstraint interface.
+func (c *runeSpanConstraint) RuneSpan() RuneSpan {
+	return c.span
+}
+
+// RuneConstraint conforms RuneSpanConstraint interface.
+func (c *runeSpanConstraint) RuneConstraint() RuneConstraint {
+	return c.c
+}
+
+// IsValid conforms constraints.Constraint interface.
+func (c *runeSpanConstraint) IsValid(v string) bool {
+	start, end := c.span.resolve(v)
+	i, found := 0, false
+	for _, r := range v {
+		if i >= end {
+			break
+		}
+		if i >= start {
+			if !c.c.IsValid(r) {
+				return false
+			}
+			found = true
+		}
+		i++
+	}
+	return found || c.kind != KindStringRuneAt
+}
+
+// runeAt returns the rune of v at index, in runes. A negative index is
+// counted from the end of v.
+func runeAt(v string, index int) (r rune, ok bool) {
+	if index < 0 {
+		index += utf8.RuneCountInString(v)
+		if index < 0 {
+			return 0, false
+		}
+	}
+	i := 0
+	for _, r := range v {
+		if i == index {
+			return r, true
+		}
+		i++
+	}
+	return 0, false
+}
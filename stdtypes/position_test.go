@@ -0,0 +1,132 @@
+package stdtypes
+
+import (
+	"testing"
+	"unicode"
+
+	"github.com/rez-go/constraints"
+)
+
+var testLetter = constraints.Func("letter", unicode.IsLetter)
+
+func TestStringRuneAt(t *testing.T) {
+	first := StringRuneAt(0, testLetter)
+	assertEq(t, "rune at index 0: letter", first.ConstraintDescription())
+	assertEq(t, KindStringRuneAt, constraints.KindOf(first))
+	assertEq(t, RuneSpan{Start: 0, End: 1}, first.RuneSpan())
+	assertEq(t, "letter", first.RuneConstraint().ConstraintDescription())
+	assertEq(t, true, first.IsValid("日本1"))
+	assertEq(t, false, first.IsValid("1日本"))
+	assertEq(t, false, first.IsValid(""))
+
+	last := StringRuneAt(-1, testLetter)
+	assertEq(t, RuneSpan{Start: -1, End: EndOfString}, last.RuneSpan())
+	assertEq(t, true, last.IsValid("1日本"))
+	assertEq(t, false, last.IsValid("日本1"))
+	assertEq(t, false, last.IsValid(""))
+
+	third := StringRuneAt(2, testLetter)
+	assertEq(t, true, third.IsValid("12本"))
+	assertEq(t, false, third.IsValid("12"))
+	assertEq(t, false, StringRuneAt(-3, testLetter).IsValid("本1"))
+	assertEq(t, true, StringRuneAt(-2, testLetter).IsValid("本1"))
+}
+
+func TestStringFirstLastRunes(t *testing.T) {
+	first := StringFirstRunes(2, testLetter)
+	assertEq(t, "first 2 runes: letter", first.ConstraintDescription())
+	assertEq(t, true, first.IsValid("日本12"))
+	assertEq(t, false, first.IsValid("日1本"))
+	assertEq(t, true, first.IsValid("日"))
+	assertEq(t, true, first.IsValid(""))
+
+	last := StringLastRunes(2, testLetter)
+	assertEq(t, "last 2 runes: letter", last.ConstraintDescription())
+	assertEq(t, 2, constraints.ParamsOf(last)["count"])
+	assertEq(t, true, last.IsValid("12日本"))
+	assertEq(t, false, last.IsValid("日本1"))
+	assertEq(t, true, StringLastRunes(0, testLetter).IsValid("1"))
+}
+
+func TestStringRunesBetween(t *testing.T) {
+	c := StringRunesBetween(1, -1, testLetter)
+	assertEq(t, "runes [1:-1]: letter", c.ConstraintDescription())
+	assertEq(t, true, c.IsValid("1日本1"))
+	assertEq(t, false, c.IsValid("1日2本1"))
+	assertEq(t, true, c.IsValid("1"))
+
+	rest := StringRunesBetween(1, EndOfString, testLetter)
+	assertEq(t, "runes [1:]: letter", rest.ConstraintDescription())
+	assertEq(t, "string.runes_from", constraints.MessageOf(rest).ID)
+	assertEq(t, true, rest.IsValid("1日本"))
+	assertEq(t, false, rest.IsValid("1日本1"))
+}
+
+func TestStringRuneSpanAllocations(t *testing.T) {
+	c := StringRunesBetween(-3, -1, RuneRange('a', 'z'))
+	legacy := StringRuneAtIndexAny(-1, RuneRange('a', 'z'))
+	allocs := testing.AllocsPerRun(100, func() {
+		c.IsValid("日本語abc")
+		legacy.IsValid("日本語abc")
+	})
+	assertEq(t, 0.0, allocs)
+}
+
+func TestStringRuneAtIndexAnyMultiByte(t *testing.T) {
+	c := StringRuneAtIndexAny(-1, RuneRange('a', 'z'))
+	assertEq(t, true, c.IsValid("日本a"))
+	assertEq(t, false, c.IsValid("a日本"))
+	assertEq(t, false, StringRuneAtIndexAny(3, RuneRange('a', 'z')).IsValid("日本"))
+}
+
+// runesBetween is the reference implementation of RuneSpan.
+func runesBetween(v string, start, end int) []rune {
+	runes := []rune(v)
+	n := len(runes)
+	if start < 0 {
+		start += n
+	}
+	if end < 0 {
+		end += n
+	}
+	if start < 0 {
+		start = 0
+	}
+	if end > n {
+		end = n
+	}
+	if start >= end {
+		return nil
+	}
+	return runes[start:end]
+}
+
+func FuzzStringRunesBetween(f *testing.F) {
+	f.Add("日本語abc", 1, -1)
+	f.Add("a\xffb", -2, EndOfString)
+	f.Add("", -1, 0)
+	f.Fuzz(func(t *testing.T, v string, start, end int) {
+		notX := constraints.Func("not x", func(r rune) bool { return r != 'x' })
+		expected := true
+		for _, r := range runesBetween(v, start, end) {
+			if r == 'x' {
+				expected = false
+			}
+		}
+		assertEq(t, expected, StringRunesBetween(start, end, notX).IsValid(v))
+	})
+}
+
+func FuzzStringRuneAt(f *testing.F) {
+	f.Add("日本語abc", -1)
+	f.Add("a\xffb", 1)
+	f.Add("", 0)
+	f.Fuzz(func(t *testing.T, v string, index int) {
+		anyRune := constraints.Func("any", func(rune) bool { return true })
+		runes := []rune(v)
+		expected := (index >= 0 && index < len(runes)) ||
+			(index < 0 && index >= -len(runes))
+		assertEq(t, expected, StringRuneAt(index, anyRune).IsValid(v))
+		assertEq(t, expected, StringRuneAtIndexAny(index, anyRune).IsValid(v))
+	})
+}
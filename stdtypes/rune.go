@@ -1,7 +1,6 @@
 package stdtypes
 
 import (
-	"fmt"
 	"strconv"
 
 	"github.com/rez-go/constraints"
@@ -17,20 +16,21 @@ var (
 		"printable rune",
 		func(v rune) bool {
 			return strconv.IsPrint(v)
-		})
+		},
+		constraints.WithMessage("rune.printable", nil),
+		constraints.WithCost(constraints.CostCheap))
 )
 
+// RuneOneOfByString creates a RuneConstraint that declares a rune as
+// valid if said rune is one of the runes of allowedRunes. The result is
+// a RuneClass, e.g., RuneOneOfByString("abc_") is described as "'_' or
+// a–c". Its message is "rune.one_of_string", with allowedRunes as the
+// argument "runes" and the message of the class as "class".
 func RuneOneOfByString(allowedRunes string) RuneConstraint {
-	return constraints.Func(
-		fmt.Sprintf("rune from %q", allowedRunes), //TODO: splits
-		func(v rune) bool {
-			for _, cr := range allowedRunes {
-				if v == cr {
-					return true
-				}
-			}
-			return false
-		})
+	rc := NewRuneClass().Runes(allowedRunes)
+	rc.byString = true
+	rc.runes = allowedRunes
+	return rc
 }
 
 // RuneRange creates a RuneConstraint that declares a rune as valid
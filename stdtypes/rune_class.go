@@ -0,0 +1,667 @@
+package stdtypes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/rez-go/constraints"
+)
+
+// Kinds of the rune class constraints.
+const (
+	KindRuneClass     constraints.Kind = "rune.class"
+	KindStringRunesIn constraints.Kind = "string.runes_in"
+)
+
+// A RuneInterval is a range of runes from Lo to Hi, inclusive.
+//
+// API status: experimental
+type RuneInterval struct {
+	Lo rune
+	Hi rune
+}
+
+// A RuneClass is a set of runes, like a character class of regular
+// expressions. It's built from ranges, explicit runes, the tables of
+// package unicode, other classes and negations, e.g.,
+//
+//	NewRuneClass().Range('A', 'Z').Range('0', '9').Runes("_")
+//
+// which is described as "0–9, A–Z or '_'", and could be exported as
+// the regular expression "[0-9A-Z_]".
+//
+// A RuneClass is immutable; the methods which add runes return a new
+// class. It's a RuneConstraint which declares a rune as valid if the
+// rune is in the class.
+//
+// API status: experimental
+type RuneClass struct {
+	// explicit is the collapsed ranges and runes which were added
+	// directly.
+	explicit []RuneInterval
+	tables   []runeClassTable
+	// classes are the negated classes which were added. They are kept
+	// to be described as they are.
+	classes []*RuneClass
+	negated bool
+	// except is the class of the runes which were removed with
+	// Except. A class is never both negated and with except.
+	except *RuneClass
+	// set is the collapsed runes of the whole class, i.e., after
+	// the negation.
+	set []RuneInterval
+	// byString is true if the class was created by RuneOneOfByString
+	// with runes. It keeps the message of RuneOneOfByString. The
+	// classes derived from it don't.
+	byString bool
+	runes    string
+}
+
+type runeClassTable struct {
+	// name is the description of the table, e.g., "Han script".
+	name string
+	// regexp is the name of the table for \p{} in regular expressions.
+	// It's empty for the tables which package regexp doesn't support.
+	regexp string
+	table  *unicode.RangeTable
+}
+
+var (
+	_ RuneConstraint                = &RuneClass{}
+	_ constraints.KindedConstraint  = &RuneClass{}
+	_ constraints.MessageConstraint = &RuneClass{}
+)
+
+// NewRuneClass creates an empty RuneClass.
+//
+// API status: experimental
+func NewRuneClass() *RuneClass {
+	return &RuneClass{}
+}
+
+// RuneClassOf creates a RuneClass from rune constraints. It supports
+// RuneRange, RuneMatch, RuneOneOf, the other constraints which have
+// bounds, e.g., constraints.Min, RuneClass, and constraints.Negate and
+// constraints.Any of those. It returns false if any of cs is not
+// supported.
+//
+// API status: experimental
+func RuneClassOf(cs ...RuneConstraint) (*RuneClass, bool) {
+	rc := NewRuneClass()
+	for _, c := range cs {
+		other, ok := runeClassOf(c)
+		if !ok {
+			return nil, false
+		}
+		rc = rc.Union(other)
+	}
+	return rc, true
+}
+
+func runeClassOf(c RuneConstraint) (*RuneClass, bool) {
+	if rc, ok := c.(*RuneClass); ok {
+		return rc, true
+	}
+	switch constraints.KindOf(c) {
+	case constraints.KindMatch:
+		if oc, ok := c.(constraints.OperandConstraint[rune]); ok {
+			return NewRuneClass().Range(oc.Operand(), oc.Operand()), true
+		}
+	case constraints.KindOneOf:
+		if oc, ok := c.(constraints.OptionsConstraint[rune]); ok {
+			return NewRuneClass().Runes(string(oc.Options())), true
+		}
+	case constraints.KindNegate, constraints.KindAny:
+		cc, ok := c.(constraints.CompositeConstraint[rune])
+		if !ok {
+			break
+		}
+		rc, ok := RuneClassOf(cc.Children()...)
+		if !ok {
+			return nil, false
+		}
+		if constraints.KindOf(c) == constraints.KindNegate {
+			rc = rc.Negate()
+		}
+		return rc, true
+	}
+	if bc, ok := c.(constraints.BoundedConstraint[rune]); ok {
+		b := bc.Bounds()
+		lo, hi := rune(0), rune(unicode.MaxRune)
+		if b.HasMin {
+			lo = b.Min
+			if b.MinExclusive {
+				lo++
+			}
+		}
+		if b.HasMax {
+			hi = b.Max
+			if b.MaxExclusive {
+				hi--
+			}
+		}
+		return NewRuneClass().Range(lo, hi), true
+	}
+	return nil, false
+}
+
+// Range returns a new class with the runes from lo to hi, inclusive,
+// added.
+func (c *RuneClass) Range(lo, hi rune) *RuneClass {
+	rc := c.base()
+	if lo <= hi {
+		rc.explicit = collapseRuneIntervals(append(rc.explicit, RuneInterval{Lo: lo, Hi: hi}))
+	}
+	return rc.update()
+}
+
+// Runes returns a new class with each of runes added.
+func (c *RuneClass) Runes(runes string) *RuneClass {
+	rc := c.base()
+	for _, r := range runes {
+		rc.explicit = append(rc.explicit, RuneInterval{Lo: r, Hi: r})
+	}
+	rc.explicit = collapseRuneIntervals(rc.explicit)
+	return rc.update()
+}
+
+// Table returns a new class with the runes of table t added. The tables
+// of package unicode are described by their names, e.g., unicode.Han
+// as "Han script" and unicode.Lu as "uppercase letter". The runes of
+// other tables are added as ranges.
+func (c *RuneClass) Table(t *unicode.RangeTable) *RuneClass {
+	rc := c.base()
+	if name, re := rangeTableName(t); name != "" {
+		rc.tables = append(rc.tables, runeClassTable{name: name, regexp: re, table: t})
+	} else {
+		rc.explicit = collapseRuneIntervals(append(rc.explicit, rangeTableIntervals(t)...))
+	}
+	return rc.update()
+}
+
+// Union returns a new class with the runes of others added.
+func (c *RuneClass) Union(others ...*RuneClass) *RuneClass {
+	rc := c.base()
+	for _, o := range others {
+		if o.negated {
+			rc.classes = append(rc.classes, o)
+			continue
+		}
+		rc.explicit = collapseRuneIntervals(append(rc.explicit, o.explicit...))
+		rc.tables = append(rc.tables, o.tables...)
+		rc.classes = append(rc.classes, o.classes...)
+	}
+	return rc.update()
+}
+
+// Except returns a new class without the runes of others, e.g.,
+//
+//	RuneCategory("Cf").Except(NewRuneClass().Runes("\u200c\u200d"))
+//
+// is described as "format except U+200C or U+200D".
+func (c *RuneClass) Except(others ...*RuneClass) *RuneClass {
+	rc := c.base()
+	if rc.except == nil {
+		rc.except = NewRuneClass()
+	}
+	rc.except = rc.except.Union(others...)
+	return rc.update()
+}
+
+// Negate returns a new class which contains all the runes which are not
+// in the class.
+func (c *RuneClass) Negate() *RuneClass {
+	if c.except != nil {
+		return (&RuneClass{classes: []*RuneClass{c}, negated: true}).update()
+	}
+	rc := c.copy()
+	rc.negated = !rc.negated
+	return rc.update()
+}
+
+// Negated returns true if the class is a negation, i.e., it's described
+// by the runes which it doesn't contain.
+func (c *RuneClass) Negated() bool {
+	return c.negated
+}
+
+// Ranges returns the runes of the class as sorted, non-overlapping and
+// non-adjacent ranges.
+func (c *RuneClass) Ranges() []RuneInterval {
+	ranges := make([]RuneInterval, len(c.set))
+	copy(ranges, c.set)
+	return ranges
+}
+
+// Contains returns true if r is in the class.
+func (c *RuneClass) Contains(r rune) bool {
+	i := sort.Search(len(c.set), func(i int) bool { return c.set[i].Hi >= r })
+	return i < len(c.set) && c.set[i].Lo <= r
+}
+
+// IsValid conforms constraints.Constraint interface.
+func (c *RuneClass) IsValid(r rune) bool {
+	return c.Contains(r)
+}
+
+// ConstraintDescription conforms constraints.Constraint interface.
+func (c *RuneClass) ConstraintDescription() string {
+	items := c.items()
+	desc := joinRuneClassItems(items)
+	if c.negated {
+		if len(items) == 0 {
+			return "any rune"
+		}
+		return "any rune except " + desc
+	}
+	if c.except != nil {
+		return desc + " except " + c.except.ConstraintDescription()
+	}
+	return desc
+}
+
+// ConstraintKind conforms constraints.KindedConstraint interface.
+func (c *RuneClass) ConstraintKind() constraints.Kind {
+	return KindRuneClass
+}
+
+// ConstraintMessage conforms constraints.MessageConstraint interface.
+func (c *RuneClass) ConstraintMessage() constraints.Message {
+	items := c.items()
+	var msg constraints.Message
+	switch len(items) {
+	case 0:
+		msg = constraints.Message{ID: "rune.class_empty"}
+	case 1:
+		msg = constraints.Message{ID: "rune.class_single",
+			Args: map[string]any{"item": items[0]}}
+	default:
+		head := make([]any, 0, len(items)-1)
+		for _, item := range items[:len(items)-1] {
+			head = append(head, item)
+		}
+		msg = constraints.Message{ID: string(KindRuneClass),
+			Args: map[string]any{"items": head, "last": items[len(items)-1]}}
+	}
+	if c.negated {
+		if len(items) == 0 {
+			msg = constraints.Message{ID: "rune.class_any"}
+		} else {
+			msg.Text = c.Negate().ConstraintDescription()
+			msg = constraints.Message{ID: "rune.class_except",
+				Args: map[string]any{"class": msg}}
+		}
+	}
+	if c.except != nil {
+		msg.Text = joinRuneClassItems(items)
+		msg = constraints.Message{ID: "rune.class_minus",
+			Args: map[string]any{"class": msg, "except": c.except.ConstraintMessage()}}
+	}
+	msg.Text = c.ConstraintDescription()
+	if c.byString {
+		msg = constraints.Message{ID: "rune.one_of_string",
+			Args: map[string]any{"runes": c.runes, "class": msg},
+			Text: msg.Text}
+	}
+	return msg
+}
+
+// Regexp returns the class as a character class of package regexp,
+// e.g., "[0-9A-Z_]" or "[^\p{Han}]".
+func (c *RuneClass) Regexp() string {
+	var sb strings.Builder
+	if len(c.classes) > 0 || c.except != nil {
+		// Negations and subtractions could not be nested in a character
+		// class.
+		writeRegexpIntervals(&sb, c.set)
+		if len(c.set) == 0 {
+			return `[^\x00-\x{10FFFF}]`
+		}
+		return "[" + sb.String() + "]"
+	}
+	writeRegexpIntervals(&sb, c.explicit)
+	for _, t := range c.tables {
+		if t.regexp != "" {
+			sb.WriteString(`\p{` + t.regexp + `}`)
+		} else {
+			writeRegexpIntervals(&sb, rangeTableIntervals(t.table))
+		}
+	}
+	if sb.Len() == 0 {
+		if c.negated {
+			return `[\x00-\x{10FFFF}]`
+		}
+		return `[^\x00-\x{10FFFF}]`
+	}
+	if c.negated {
+		return "[^" + sb.String() + "]"
+	}
+	return "[" + sb.String() + "]"
+}
+
+// items returns the descriptions of the parts of the class, without
+// the negation.
+func (c *RuneClass) items() []string {
+	items := make([]string, 0, len(c.explicit)+len(c.tables)+len(c.classes))
+	for _, iv := range c.explicit {
+		switch {
+		case iv.Lo == iv.Hi:
+			items = append(items, quoteRune(iv.Lo))
+		case iv.Lo+1 == iv.Hi:
+			items = append(items, quoteRune(iv.Lo), quoteRune(iv.Hi))
+		default:
+			items = append(items, runeText(iv.Lo)+"–"+runeText(iv.Hi))
+		}
+	}
+	for _, t := range c.tables {
+		items = append(items, t.name)
+	}
+	for _, o := range c.classes {
+		items = append(items, o.ConstraintDescription())
+	}
+	return items
+}
+
+// joinRuneClassItems returns the description of a class with items,
+// without the negation and the runes which were removed.
+func joinRuneClassItems(items []string) string {
+	switch len(items) {
+	case 0:
+		return "no runes"
+	case 1:
+		return items[0]
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " or " + items[len(items)-1]
+}
+
+// base returns a copy of c to add runes to. If c is negated or has
+// runes removed, the copy contains c as a whole.
+func (c *RuneClass) base() *RuneClass {
+	if c.negated || c.except != nil {
+		return &RuneClass{classes: []*RuneClass{c}}
+	}
+	return c.copy()
+}
+
+func (c *RuneClass) copy() *RuneClass {
+	return &RuneClass{
+		explicit: append([]RuneInterval(nil), c.explicit...),
+		tables:   append([]runeClassTable(nil), c.tables...),
+		classes:  append([]*RuneClass(nil), c.classes...),
+		negated:  c.negated,
+		except:   c.except,
+	}
+}
+
+// update computes the set of the class.
+func (c *RuneClass) update() *RuneClass {
+	set := append([]RuneInterval(nil), c.explicit...)
+	for _, t := range c.tables {
+		set = append(set, rangeTableIntervals(t.table)...)
+	}
+	for _, o := range c.classes {
+		set = append(set, o.set...)
+	}
+	set = collapseRuneIntervals(set)
+	if c.except != nil {
+		set = subtractRuneIntervals(set, c.except.set)
+	}
+	if c.negated {
+		set = complementRuneIntervals(set)
+	}
+	c.set = set
+	return c
+}
+
+// collapseRuneIntervals sorts intervals and merges those which overlap
+// or are adjacent.
+func collapseRuneIntervals(intervals []RuneInterval) []RuneInterval {
+	if len(intervals) == 0 {
+		return nil
+	}
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i].Lo < intervals[j].Lo })
+	collapsed := intervals[:1]
+	for _, iv := range intervals[1:] {
+		last := &collapsed[len(collapsed)-1]
+		if iv.Lo <= last.Hi+1 {
+			if iv.Hi > last.Hi {
+				last.Hi = iv.Hi
+			}
+			continue
+		}
+		collapsed = append(collapsed, iv)
+	}
+	return collapsed
+}
+
+// subtractRuneIntervals returns the ranges of the runes which are in
+// the collapsed intervals but not in the collapsed others.
+func subtractRuneIntervals(intervals, others []RuneInterval) []RuneInterval {
+	var result []RuneInterval
+	for _, iv := range intervals {
+		lo := iv.Lo
+		for _, o := range others {
+			if o.Hi < lo || o.Lo > iv.Hi {
+				continue
+			}
+			if o.Lo > lo {
+				result = append(result, RuneInterval{Lo: lo, Hi: o.Lo - 1})
+			}
+			lo = o.Hi + 1
+		}
+		if lo <= iv.Hi {
+			result = append(result, RuneInterval{Lo: lo, Hi: iv.Hi})
+		}
+	}
+	return result
+}
+
+// complementRuneIntervals returns the ranges of the runes which are not
+// in the collapsed intervals.
+func complementRuneIntervals(intervals []RuneInterval) []RuneInterval {
+	var result []RuneInterval
+	next := rune(0)
+	for _, iv := range intervals {
+		if iv.Lo > next {
+			result = append(result, RuneInterval{Lo: next, Hi: iv.Lo - 1})
+		}
+		next = iv.Hi + 1
+	}
+	if next <= unicode.MaxRune {
+		result = append(result, RuneInterval{Lo: next, Hi: unicode.MaxRune})
+	}
+	return result
+}
+
+func rangeTableIntervals(t *unicode.RangeTable) []RuneInterval {
+	var intervals []RuneInterval
+	add := func(lo, hi, stride rune) {
+		if stride == 1 {
+			intervals = append(intervals, RuneInterval{Lo: lo, Hi: hi})
+			return
+		}
+		for r := lo; r <= hi; r += stride {
+			intervals = append(intervals, RuneInterval{Lo: r, Hi: r})
+		}
+	}
+	for _, r := range t.R16 {
+		add(rune(r.Lo), rune(r.Hi), rune(r.Stride))
+	}
+	for _, r := range t.R32 {
+		add(rune(r.Lo), rune(r.Hi), rune(r.Stride))
+	}
+	return collapseRuneIntervals(intervals)
+}
+
+// rangeTableName returns the description of t and its name for
+// regular expressions if t is one of the tables of package unicode.
+func rangeTableName(t *unicode.RangeTable) (name, re string) {
+	for _, n := range sortedTableNames(unicode.Categories) {
+		if unicode.Categories[n] == t {
+			if name, ok := categoryNames[n]; ok {
+				return name, n
+			}
+			return "category " + n, n
+		}
+	}
+	for _, n := range sortedTableNames(unicode.Scripts) {
+		if unicode.Scripts[n] == t {
+			return n + " script", n
+		}
+	}
+	for _, n := range sortedTableNames(unicode.Properties) {
+		if unicode.Properties[n] == t {
+			return strings.ToLower(strings.ReplaceAll(n, "_", " ")), ""
+		}
+	}
+	return "", ""
+}
+
+// categoryNames are the long names of the general categories.
+var categoryNames = map[string]string{
+	"C": "other", "Cc": "control", "Cf": "format", "Co": "private use", "Cs": "surrogate",
+	"L": "letter", "Ll": "lowercase letter", "Lm": "modifier letter", "Lo": "other letter",
+	"Lt": "titlecase letter", "Lu": "uppercase letter",
+	"M": "mark", "Mc": "spacing mark", "Me": "enclosing mark", "Mn": "nonspacing mark",
+	"N": "number", "Nd": "decimal number", "Nl": "letter number", "No": "other number",
+	"P": "punctuation", "Pc": "connector punctuation", "Pd": "dash punctuation",
+	"Pe": "close punctuation", "Pf": "final punctuation", "Pi": "initial punctuation",
+	"Po": "other punctuation", "Ps": "open punctuation",
+	"S": "symbol", "Sc": "currency symbol", "Sk": "modifier symbol", "Sm": "math symbol",
+	"So": "other symbol",
+	"Z":  "separator", "Zl": "line separator", "Zp": "paragraph separator", "Zs": "space separator",
+}
+
+// sortedTableNames returns the names of tables in order. Some tables
+// have multiple names, e.g., "L" and "Letter".
+func sortedTableNames(tables map[string]*unicode.RangeTable) []string {
+	names := make([]string, 0, len(tables))
+	for n := range tables {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func writeRegexpIntervals(sb *strings.Builder, intervals []RuneInterval) {
+	for _, iv := range intervals {
+		sb.WriteString(regexpRune(iv.Lo))
+		if iv.Hi != iv.Lo {
+			if iv.Hi != iv.Lo+1 {
+				sb.WriteByte('-')
+			}
+			sb.WriteString(regexpRune(iv.Hi))
+		}
+	}
+}
+
+func regexpRune(r rune) string {
+	switch {
+	case strings.ContainsRune(`\]-[^`, r):
+		return `\` + string(r)
+	case r < 0x80 && unicode.IsPrint(r):
+		return string(r)
+	}
+	return fmt.Sprintf(`\x{%X}`, r)
+}
+
+// runeText renders a rune in a range, e.g., A in "A–Z".
+func runeText(r rune) string {
+	if unicode.IsLetter(r) || unicode.IsDigit(r) {
+		return string(r)
+	}
+	return quoteRune(r)
+}
+
+func quoteRune(r rune) string {
+	if unicode.IsPrint(r) {
+		return fmt.Sprintf("'%c'", r)
+	}
+	return fmt.Sprintf("%U", r)
+}
+
+// StringRunesIn creates a Constraint which declares a string as valid
+// if each of its runes is in class, e.g.,
+//
+//	StringRunesIn(NewRuneClass().Range('A', 'Z').Range('0', '9').Runes("_"))
+//
+// is described as "each rune: 0–9, A–Z or '_'".
+//
+// API status: experimental
+func StringRunesIn(class *RuneClass) StringConstraint {
+	return &runesInConstraint{class: class}
+}
+
+// RuneClassConstraint is implemented by the string constraints which
+// are defined by a RuneClass, e.g., StringRunesIn and StringNoRunesIn.
+// Exporters could use the regular expression of the class.
+//
+// API status: experimental
+type RuneClassConstraint interface {
+	StringConstraint
+
+	// RuneClass returns the class of the constraint.
+	RuneClass() *RuneClass
+}
+
+type runesInConstraint struct {
+	class *RuneClass
+	// anyMsgs are the messages of the constraints of StringRunesAny,
+	// or nil if the constraint was created by StringRunesIn. They keep
+	// the message of StringRunesAny.
+	anyMsgs []constraints.Message
+}
+
+var (
+	_ RuneClassConstraint           = &runesInConstraint{}
+	_ constraints.KindedConstraint  = &runesInConstraint{}
+	_ constraints.MessageConstraint = &runesInConstraint{}
+)
+
+// ConstraintDescription conforms constraints.Constraint interface.
+func (c *runesInConstraint) ConstraintDescription() string {
+	return "each rune: " + c.class.ConstraintDescription()
+}
+
+// ConstraintKind conforms constraints.KindedConstraint interface.
+func (c *runesInConstraint) ConstraintKind() constraints.Kind {
+	return KindStringRunesIn
+}
+
+// ConstraintMessage conforms constraints.MessageConstraint interface.
+func (c *runesInConstraint) ConstraintMessage() constraints.Message {
+	if c.anyMsgs != nil {
+		return constraints.Message{
+			ID: "string.runes_any",
+			Args: map[string]any{
+				"form":        "class",
+				"constraints": c.anyMsgs,
+				"class":       c.class.ConstraintMessage(),
+			},
+			Text: c.ConstraintDescription(),
+		}
+	}
+	return constraints.Message{
+		ID:   string(KindStringRunesIn),
+		Args: map[string]any{"class": c.class.ConstraintMessage()},
+		Text: c.ConstraintDescription(),
+	}
+}
+
+// RuneClass conforms RuneClassConstraint interface. It returns the
+// class of the runes.
+func (c *runesInConstraint) RuneClass() *RuneClass {
+	return c.class
+}
+
+// IsValid conforms constraints.Constraint interface.
+func (c *runesInConstraint) IsValid(v string) bool {
+	for _, r := range v {
+		if !c.class.Contains(r) {
+			return false
+		}
+	}
+	return true
+}
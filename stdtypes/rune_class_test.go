@@ -0,0 +1,130 @@
+package stdtypes
+
+import (
+	"regexp"
+	"testing"
+	"unicode"
+
+	"github.com/rez-go/constraints"
+)
+
+func TestRuneClass(t *testing.T) {
+	c := NewRuneClass().Range('A', 'Z').Range('0', '9').Runes("_")
+	assertEq(t, "0–9, A–Z or '_'", c.ConstraintDescription())
+	assertEq(t, `[0-9A-Z_]`, c.Regexp())
+	assertEq(t, []RuneInterval{{'0', '9'}, {'A', 'Z'}, {'_', '_'}}, c.Ranges())
+	assertEq(t, true, c.IsValid('Q'))
+	assertEq(t, true, c.IsValid('_'))
+	assertEq(t, false, c.IsValid('a'))
+	assertEq(t, KindRuneClass, constraints.KindOf(c))
+
+	// The original class is not modified.
+	lower := c.Range('a', 'z')
+	assertEq(t, false, c.IsValid('a'))
+	assertEq(t, true, lower.IsValid('a'))
+}
+
+func TestRuneClassCollapse(t *testing.T) {
+	c := NewRuneClass().Runes("cab").Range('d', 'f').Range('x', 'y').Runes("-")
+	assertEq(t, []RuneInterval{{'-', '-'}, {'a', 'f'}, {'x', 'y'}}, c.Ranges())
+	assertEq(t, "'-', a–f, 'x' or 'y'", c.ConstraintDescription())
+	assertEq(t, `[\-a-fxy]`, c.Regexp())
+	assertEq(t, "'_' or a–c", RuneOneOfByString("abc_").ConstraintDescription())
+}
+
+func TestRuneOneOfByStringMessage(t *testing.T) {
+	c := RuneOneOfByString("abc_")
+	assertEq(t, "rune.one_of_string", constraints.CodeOf(c))
+	assertEq(t, "abc_", constraints.ParamsOf(c)["runes"])
+	assertEq(t, KindRuneClass, constraints.KindOf(c))
+	// The classes derived from it have their own messages.
+	assertEq(t, "rune.class", constraints.CodeOf(c.(*RuneClass).Runes("-")))
+}
+
+func TestRuneClassExcept(t *testing.T) {
+	c := NewRuneClass().Range('a', 'z').Except(NewRuneClass().Runes("aeiou"))
+	assertEq(t, "a–z except 'a', 'e', 'i', 'o' or 'u'", c.ConstraintDescription())
+	assertEq(t, []RuneInterval{{'b', 'd'}, {'f', 'h'}, {'j', 'n'}, {'p', 't'}, {'v', 'z'}}, c.Ranges())
+	assertEq(t, `[b-df-hj-np-tv-z]`, c.Regexp())
+	assertEq(t, "rune.class_minus", constraints.CodeOf(c))
+	assertEq(t, true, c.IsValid('b'))
+	assertEq(t, false, c.IsValid('a'))
+
+	// Runes added afterwards are not removed.
+	assertEq(t, true, c.Runes("a").IsValid('a'))
+	assertEq(t, "'a' or a–z except 'a', 'e', 'i', 'o' or 'u'", c.Runes("a").ConstraintDescription())
+	assertEq(t, false, c.Negate().IsValid('b'))
+	assertEq(t, true, c.Negate().IsValid('e'))
+}
+
+func TestRuneClassTable(t *testing.T) {
+	c := NewRuneClass().Table(unicode.Han).Runes("ー")
+	assertEq(t, "'ー' or Han script", c.ConstraintDescription())
+	assertEq(t, `[\x{30FC}\p{Han}]`, c.Regexp())
+	assertEq(t, true, c.IsValid('漢'))
+	assertEq(t, false, c.IsValid('a'))
+
+	digits := &unicode.RangeTable{R16: []unicode.Range16{{Lo: '0', Hi: '8', Stride: 2}}}
+	assertEq(t, "'0', '2', '4', '6' or '8'", NewRuneClass().Table(digits).ConstraintDescription())
+}
+
+func TestRuneClassNegate(t *testing.T) {
+	c := NewRuneClass().Runes("_").Table(unicode.Zs).Negate()
+	assertEq(t, "any rune except '_' or space separator", c.ConstraintDescription())
+	assertEq(t, `[^_\p{Zs}]`, c.Regexp())
+	assertEq(t, true, c.IsValid('a'))
+	assertEq(t, false, c.IsValid(' '))
+	assertEq(t, "'_' or space separator", c.Negate().ConstraintDescription())
+
+	u := NewRuneClass().Range('0', '9').Union(NewRuneClass().Range('a', 'z').Negate())
+	assertEq(t, "0–9 or any rune except a–z", u.ConstraintDescription())
+	assertEq(t, true, u.IsValid('5'))
+	assertEq(t, false, u.IsValid('k'))
+	assertEq(t, "[\\x{0}-`{-\\x{10FFFF}]", u.Regexp())
+
+	assertEq(t, "any rune", NewRuneClass().Negate().ConstraintDescription())
+}
+
+func TestRuneClassRegexp(t *testing.T) {
+	classes := []*RuneClass{
+		NewRuneClass(),
+		NewRuneClass().Negate(),
+		NewRuneClass().Runes(`]\^-[`),
+		NewRuneClass().Range(0, 0x1F).Table(unicode.Greek),
+		NewRuneClass().Range('0', '9').Union(NewRuneClass().Range('a', 'z').Negate()),
+	}
+	for _, c := range classes {
+		re := regexp.MustCompile(`^` + c.Regexp() + `$`)
+		for _, r := range []rune{0, '\n', ' ', '-', '0', '[', '\\', ']', '^', 'a', 'z', 'α', '漢', unicode.MaxRune} {
+			assertEq(t, c.IsValid(r), re.MatchString(string(r)), "%s %q", c.Regexp(), r)
+		}
+	}
+}
+
+func TestRuneClassOf(t *testing.T) {
+	c, ok := RuneClassOf(
+		RuneRange('A', 'Z'),
+		RuneRange('a', 'z'),
+		RuneRange('0', '9'),
+		RuneMatch('_'),
+	)
+	assertEq(t, true, ok)
+	assertEq(t, "0–9, A–Z, '_' or a–z", c.ConstraintDescription())
+
+	c, ok = RuneClassOf(constraints.Negate[rune](RuneOneOf('a', 'b'), "not a or b"))
+	assertEq(t, true, ok)
+	assertEq(t, "any rune except 'a' or 'b'", c.ConstraintDescription())
+
+	_, ok = RuneClassOf(PrintableRune)
+	assertEq(t, false, ok)
+}
+
+func TestStringRunesIn(t *testing.T) {
+	class, _ := RuneClassOf(RuneRange('a', 'z'), RuneRange('0', '9'), RuneMatch('_'))
+	c := StringRunesIn(class)
+	assertEq(t, "each rune: 0–9, '_' or a–z", c.ConstraintDescription())
+	assertEq(t, KindStringRunesIn, constraints.KindOf(c))
+	assertEq(t, true, c.IsValid("hello_123"))
+	assertEq(t, false, c.IsValid("Hello"))
+	assertEq(t, true, c.IsValid(""))
+}
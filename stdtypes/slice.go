@@ -0,0 +1,361 @@
+package stdtypes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rez-go/constraints"
+)
+
+// Kinds of the slice constraints. The length constraints of slices
+// have the same kinds as those of strings, e.g., KindLengthMin.
+const (
+	KindSliceEach        constraints.Kind = "slice.each"
+	KindSliceSome        constraints.Kind = "slice.some"
+	KindSliceAtLeastN    constraints.Kind = "slice.at_least_n"
+	KindSliceExactlyN    constraints.Kind = "slice.exactly_n"
+	KindSliceUnique      constraints.Kind = "slice.unique"
+	KindSliceContains    constraints.Kind = "slice.contains"
+	KindSliceContainsAll constraints.Kind = "slice.contains_all"
+)
+
+// SliceLength returns a Constraint that will declare a slice as valid
+// if its length is exactly as specified.
+//
+// API status: experimental
+func SliceLength[SliceT ~[]E, E any](specifiedLength int) constraints.Constraint[SliceT] {
+	if specifiedLength < 0 {
+		panic("specifiedLength must be zero or a positive integer")
+	}
+	return &lengthConstraint[SliceT]{min: specifiedLength, max: specifiedLength, unit: LengthUnitElements, length: sliceLength[SliceT]}
+}
+
+// SliceMaxLength returns a Constraint that will declare a slice as
+// valid if its length is at most maxLength.
+//
+// API status: experimental
+func SliceMaxLength[SliceT ~[]E, E any](maxLength int) constraints.Constraint[SliceT] {
+	if maxLength < 0 {
+		panic("maxLength must be zero or a positive integer")
+	}
+	return &lengthConstraint[SliceT]{min: -1, max: maxLength, unit: LengthUnitElements, length: sliceLength[SliceT]}
+}
+
+// SliceMinLength returns a Constraint that will declare a slice as
+// valid if its length is at least minLength.
+//
+// API status: experimental
+func SliceMinLength[SliceT ~[]E, E any](minLength int) constraints.Constraint[SliceT] {
+	if minLength < 0 {
+		panic("minLength must be zero or a positive integer")
+	}
+	return &lengthConstraint[SliceT]{min: minLength, max: -1, unit: LengthUnitElements, length: sliceLength[SliceT]}
+}
+
+// SliceLengthRange returns a Constraint that will declare a slice as
+// valid if its length is between min and max, inclusive.
+//
+// API status: experimental
+func SliceLengthRange[SliceT ~[]E, E any](min, max int) constraints.Constraint[SliceT] {
+	if min < 0 {
+		panic("min must be zero or a positive integer")
+	}
+	if max < 0 {
+		panic("max must be zero or a positive integer")
+	}
+	return &lengthConstraint[SliceT]{min: min, max: max, unit: LengthUnitElements, length: sliceLength[SliceT]}
+}
+
+func sliceLength[SliceT ~[]E, E any](v SliceT) int { return len(v) }
+
+// ElementsConstraint is implemented by constraints which validate
+// the elements of collections, e.g., Each.
+type ElementsConstraint interface {
+	constraints.ConstraintBase
+
+	// ElementConstraint returns the constraint of the elements.
+	ElementConstraint() constraints.ConstraintBase
+}
+
+// Each creates a Constraint which declares a slice as valid if all of
+// its elements satisfy constraint c.
+//
+// Its FieldErrors method reports each invalid element at its index,
+// e.g., "tags[2]" for a slice in the field "tags" of a Struct.
+//
+// API status: experimental
+func Each[SliceT ~[]E, E any](c constraints.Constraint[E]) constraints.Constraint[SliceT] {
+	return &matchCountConstraint[SliceT, E]{kind: KindSliceEach, c: c}
+}
+
+// Some creates a Constraint which declares a slice as valid if at
+// least one of its elements satisfies constraint c.
+//
+// API status: experimental
+func Some[SliceT ~[]E, E any](c constraints.Constraint[E]) constraints.Constraint[SliceT] {
+	return &matchCountConstraint[SliceT, E]{kind: KindSliceSome, c: c, n: 1}
+}
+
+// AtLeastN creates a Constraint which declares a slice as valid if at
+// least n of its elements satisfy constraint c.
+//
+// API status: experimental
+func AtLeastN[SliceT ~[]E, E any](n int, c constraints.Constraint[E]) constraints.Constraint[SliceT] {
+	if n < 0 {
+		panic("n must be zero or a positive integer")
+	}
+	return &matchCountConstraint[SliceT, E]{kind: KindSliceAtLeastN, c: c, n: n}
+}
+
+// ExactlyN creates a Constraint which declares a slice as valid if
+// exactly n of its elements satisfy constraint c.
+//
+// API status: experimental
+func ExactlyN[SliceT ~[]E, E any](n int, c constraints.Constraint[E]) constraints.Constraint[SliceT] {
+	if n < 0 {
+		panic("n must be zero or a positive integer")
+	}
+	return &matchCountConstraint[SliceT, E]{kind: KindSliceExactlyN, c: c, n: n}
+}
+
+// matchCountConstraint counts the elements which satisfy c. The
+// requirement on the count depends on the kind.
+type matchCountConstraint[SliceT ~[]E, E any] struct {
+	kind constraints.Kind
+	c    constraints.Constraint[E]
+	n    int
+}
+
+var (
+	_ ElementsConstraint            = &matchCountConstraint[[]int, int]{}
+	_ constraints.KindedConstraint  = &matchCountConstraint[[]int, int]{}
+	_ constraints.MessageConstraint = &matchCountConstraint[[]int, int]{}
+)
+
+// ConstraintDescription conforms constraints.Constraint interface.
+func (c *matchCountConstraint[SliceT, E]) ConstraintDescription() string {
+	desc := c.c.ConstraintDescription()
+	switch c.kind {
+	case KindSliceEach:
+		return "each: " + desc
+	case KindSliceSome:
+		return "some: " + desc
+	case KindSliceAtLeastN:
+		return fmt.Sprintf("at least %d: %s", c.n, desc)
+	}
+	return fmt.Sprintf("exactly %d: %s", c.n, desc)
+}
+
+// ConstraintKind conforms constraints.KindedConstraint interface.
+func (c *matchCountConstraint[SliceT, E]) ConstraintKind() constraints.Kind {
+	return c.kind
+}
+
+// ConstraintMessage conforms constraints.MessageConstraint interface.
+func (c *matchCountConstraint[SliceT, E]) ConstraintMessage() constraints.Message {
+	args := map[string]any{"constraint": constraints.MessageOf(c.c)}
+	if c.kind == KindSliceAtLeastN || c.kind == KindSliceExactlyN {
+		args["count"] = c.n
+	}
+	return constraints.Message{ID: string(c.kind), Args: args, Text: c.ConstraintDescription()}
+}
+
+// ElementConstraint conforms ElementsConstraint interface.
+func (c *matchCountConstraint[SliceT, E]) ElementConstraint() constraints.ConstraintBase {
+	return c.c
+}
+
+// IsValid conforms constraints.Constraint interface.
+func (c *matchCountConstraint[SliceT, E]) IsValid(v SliceT) bool {
+	if c.kind == KindSliceEach {
+		for _, e := range v {
+			if !c.c.IsValid(e) {
+				return false
+			}
+		}
+		return true
+	}
+	count := 0
+	for _, e := range v {
+		if c.c.IsValid(e) {
+			count++
+			if count >= c.n && c.kind != KindSliceExactlyN {
+				return true
+			}
+		}
+	}
+	return count == c.n
+}
+
+// FieldErrors returns the errors of the invalid elements of v at their
+// indexes for Each. For the others, it returns an error for v itself,
+// if v is invalid.
+func (c *matchCountConstraint[SliceT, E]) FieldErrors(v SliceT) constraints.ErrorList {
+	var errs constraints.ErrorList
+	if c.kind != KindSliceEach {
+		if !c.IsValid(v) {
+			errs.Add(nil, constraints.ViolationError[SliceT](c))
+		}
+		return errs
+	}
+	for i, e := range v {
+		errs.Add(constraints.FieldPath{}.Index(i), elementError(e, c.c))
+	}
+	return errs
+}
+
+// elementError validates element e against constraint c. If c reports
+// the errors of the fields of e, e.g., a Struct, they are returned as
+// an ErrorList. See constraints.ValidateField.
+func elementError[E any](e E, c constraints.Constraint[E]) error {
+	var errs constraints.ErrorList
+	constraints.ValidateField(&errs, nil, e, c)
+	return errs.Err()
+}
+
+// UniqueItems creates a Constraint which declares a slice as valid if
+// none of its elements appear more than once.
+//
+// Its FieldErrors method reports each repeated occurrence at its
+// index.
+//
+// API status: experimental
+func UniqueItems[SliceT ~[]E, E comparable]() constraints.Constraint[SliceT] {
+	return &uniqueItemsConstraint[SliceT, E]{}
+}
+
+type uniqueItemsConstraint[SliceT ~[]E, E comparable] struct{}
+
+var (
+	_ constraints.KindedConstraint  = &uniqueItemsConstraint[[]int, int]{}
+	_ constraints.MessageConstraint = &uniqueItemsConstraint[[]int, int]{}
+)
+
+// ConstraintDescription conforms constraints.Constraint interface.
+func (c *uniqueItemsConstraint[SliceT, E]) ConstraintDescription() string {
+	return "unique items"
+}
+
+// ConstraintKind conforms constraints.KindedConstraint interface.
+func (c *uniqueItemsConstraint[SliceT, E]) ConstraintKind() constraints.Kind {
+	return KindSliceUnique
+}
+
+// ConstraintMessage conforms constraints.MessageConstraint interface.
+func (c *uniqueItemsConstraint[SliceT, E]) ConstraintMessage() constraints.Message {
+	return constraints.Message{ID: string(KindSliceUnique), Text: c.ConstraintDescription()}
+}
+
+// IsValid conforms constraints.Constraint interface.
+func (c *uniqueItemsConstraint[SliceT, E]) IsValid(v SliceT) bool {
+	return len(c.duplicates(v)) == 0
+}
+
+// FieldErrors reports each repeated occurrence of an element at its
+// index.
+func (c *uniqueItemsConstraint[SliceT, E]) FieldErrors(v SliceT) constraints.ErrorList {
+	var errs constraints.ErrorList
+	for _, i := range c.duplicates(v) {
+		errs.Add(constraints.FieldPath{}.Index(i), constraints.ViolationError[SliceT](c))
+	}
+	return errs
+}
+
+// duplicates returns the indexes of the elements which appeared
+// earlier in v.
+func (c *uniqueItemsConstraint[SliceT, E]) duplicates(v SliceT) []int {
+	var dups []int
+	seen := make(map[E]struct{}, len(v))
+	for i, e := range v {
+		if _, ok := seen[e]; ok {
+			dups = append(dups, i)
+			continue
+		}
+		seen[e] = struct{}{}
+	}
+	return dups
+}
+
+// Contains creates a Constraint which declares a slice as valid if it
+// contains element e.
+//
+// API status: experimental
+func Contains[SliceT ~[]E, E comparable](e E) constraints.Constraint[SliceT] {
+	return &containsConstraint[SliceT, E]{kind: KindSliceContains, elements: []E{e}}
+}
+
+// ContainsAll creates a Constraint which declares a slice as valid if
+// it contains all of elements.
+//
+// API status: experimental
+func ContainsAll[SliceT ~[]E, E comparable](elements ...E) constraints.Constraint[SliceT] {
+	copies := make([]E, len(elements))
+	copy(copies, elements)
+	return &containsConstraint[SliceT, E]{kind: KindSliceContainsAll, elements: copies}
+}
+
+type containsConstraint[SliceT ~[]E, E comparable] struct {
+	kind     constraints.Kind
+	elements []E
+}
+
+var (
+	_ constraints.KindedConstraint  = &containsConstraint[[]int, int]{}
+	_ constraints.MessageConstraint = &containsConstraint[[]int, int]{}
+)
+
+// ConstraintDescription conforms constraints.Constraint interface.
+func (c *containsConstraint[SliceT, E]) ConstraintDescription() string {
+	if c.kind == KindSliceContains {
+		return fmt.Sprintf("contains %v", c.elements[0])
+	}
+	strs := make([]string, 0, len(c.elements))
+	for _, e := range c.elements {
+		strs = append(strs, fmt.Sprint(e))
+	}
+	return "contains all of [" + strings.Join(strs, ", ") + "]"
+}
+
+// ConstraintKind conforms constraints.KindedConstraint interface.
+func (c *containsConstraint[SliceT, E]) ConstraintKind() constraints.Kind {
+	return c.kind
+}
+
+// ConstraintMessage conforms constraints.MessageConstraint interface.
+func (c *containsConstraint[SliceT, E]) ConstraintMessage() constraints.Message {
+	var args map[string]any
+	if c.kind == KindSliceContains {
+		args = map[string]any{"value": c.elements[0]}
+	} else {
+		values := make([]any, 0, len(c.elements))
+		for _, e := range c.elements {
+			values = append(values, e)
+		}
+		args = map[string]any{"values": values}
+	}
+	return constraints.Message{ID: string(c.kind), Args: args, Text: c.ConstraintDescription()}
+}
+
+// Elements returns a copy of the elements which must be contained.
+func (c *containsConstraint[SliceT, E]) Elements() []E {
+	copies := make([]E, len(c.elements))
+	copy(copies, c.elements)
+	return copies
+}
+
+// IsValid conforms constraints.Constraint interface.
+func (c *containsConstraint[SliceT, E]) IsValid(v SliceT) bool {
+	for _, want := range c.elements {
+		found := false
+		for _, e := range v {
+			if e == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
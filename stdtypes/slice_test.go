@@ -0,0 +1,123 @@
+package stdtypes
+
+import (
+	"testing"
+
+	"github.com/rez-go/constraints"
+)
+
+type testPost struct {
+	Tags []string
+}
+
+func errorPaths(errs constraints.ErrorList) []string {
+	paths := make([]string, 0, len(errs))
+	for _, fe := range errs {
+		paths = append(paths, fe.Path.String())
+	}
+	return paths
+}
+
+func TestSliceLength(t *testing.T) {
+	c := SliceLengthRange[[]int](1, 3)
+	assertEq(t, KindLengthRange, constraints.KindOf(c))
+	assertEq(t, false, c.IsValid(nil))
+	assertEq(t, true, c.IsValid([]int{1, 2, 3}))
+	assertEq(t, false, c.IsValid([]int{1, 2, 3, 4}))
+	assertEq(t, true, SliceLength[[]int](0).IsValid(nil))
+	assertEq(t, false, SliceMinLength[[]int](1).IsValid([]int{}))
+	assertEq(t, "max length 2", SliceMaxLength[[]int](2).ConstraintDescription())
+}
+
+func TestEach(t *testing.T) {
+	c := Each[[]string](StringMaxLength(3))
+	assertEq(t, "each: max length 3", c.ConstraintDescription())
+	assertEq(t, KindSliceEach, constraints.KindOf(c))
+	assertEq(t, true, c.IsValid(nil))
+	assertEq(t, true, c.IsValid([]string{"go", "api"}))
+	assertEq(t, false, c.IsValid([]string{"go", "rust"}))
+	assertEq(t, "max length 3",
+		c.(ElementsConstraint).ElementConstraint().ConstraintDescription())
+
+	post := constraints.Struct(
+		constraints.Field[testPost, []string]("tags",
+			func(p testPost) []string { return p.Tags },
+			constraints.Set[[]string](SliceMaxLength[[]string](3), c)),
+	)
+	errs := post.FieldErrors(testPost{Tags: []string{"go", "rust", "api", "python"}})
+	assertEq(t, []string{"tags", "tags[1]", "tags[3]"}, errorPaths(errs))
+	assertEq(t, "tags: required to be max length 3", errs[0].Error())
+	assertEq(t, "tags[1]: required to be max length 3", errs[1].Error())
+
+	var fieldErrs constraints.ErrorList
+	nested := constraints.Set[[]string](
+		constraints.Set[[]string](SliceMinLength[[]string](5), c),
+		UniqueItems[[]string](),
+	)
+	valid := constraints.ValidateField[[]string](&fieldErrs, constraints.Path("tags"),
+		[]string{"go", "rust", "go"}, nested)
+	assertEq(t, false, valid)
+	assertEq(t, []string{"tags", "tags[1]", "tags[2]"}, errorPaths(fieldErrs))
+}
+
+func TestEachNested(t *testing.T) {
+	c := Each[[]testPost, testPost](constraints.Struct(
+		constraints.Field[testPost, []string]("tags",
+			func(p testPost) []string { return p.Tags },
+			UniqueItems[[]string]()),
+	))
+	var errs constraints.ErrorList
+	valid := constraints.ValidateField[[]testPost](&errs, constraints.Path("posts"), []testPost{
+		{Tags: []string{"a", "b"}},
+		{Tags: []string{"a", "b", "a", "a"}},
+	}, c)
+	assertEq(t, false, valid)
+	assertEq(t, []string{"posts[1].tags[2]", "posts[1].tags[3]"}, errorPaths(errs))
+}
+
+func TestMatchCount(t *testing.T) {
+	even := constraints.Func("even", func(v int) bool { return v%2 == 0 })
+
+	some := Some[[]int](even)
+	assertEq(t, "some: even", some.ConstraintDescription())
+	assertEq(t, false, some.IsValid(nil))
+	assertEq(t, true, some.IsValid([]int{1, 2}))
+
+	atLeast := AtLeastN[[]int](2, even)
+	assertEq(t, "at least 2: even", atLeast.ConstraintDescription())
+	assertEq(t, false, atLeast.IsValid([]int{1, 2}))
+	assertEq(t, true, atLeast.IsValid([]int{2, 4, 6}))
+
+	exactly := ExactlyN[[]int](2, even)
+	assertEq(t, KindSliceExactlyN, constraints.KindOf(exactly))
+	assertEq(t, true, exactly.IsValid([]int{2, 3, 4}))
+	assertEq(t, false, exactly.IsValid([]int{2, 4, 6}))
+	assertEq(t, 2, constraints.MessageOf(exactly).Args["count"])
+
+	var errs constraints.ErrorList
+	constraints.ValidateField[[]int](&errs, constraints.Path("numbers"), []int{1}, exactly)
+	assertEq(t, []string{"numbers"}, errorPaths(errs))
+}
+
+func TestUniqueItems(t *testing.T) {
+	c := UniqueItems[[]string]()
+	assertEq(t, "unique items", c.ConstraintDescription())
+	assertEq(t, true, c.IsValid([]string{"a", "b"}))
+	assertEq(t, false, c.IsValid([]string{"a", "b", "a"}))
+	assertEq(t, nil, constraints.ValidOrError[[]string]([]string{"a"}, c))
+	assertNeq(t, nil, constraints.ValidOrError[[]string]([]string{"a", "a"}, c))
+}
+
+func TestContains(t *testing.T) {
+	c := Contains[[]string]("admin")
+	assertEq(t, "contains admin", c.ConstraintDescription())
+	assertEq(t, true, c.IsValid([]string{"user", "admin"}))
+	assertEq(t, false, c.IsValid([]string{"user"}))
+
+	all := ContainsAll[[]int](1, 2)
+	assertEq(t, "contains all of [1, 2]", all.ConstraintDescription())
+	assertEq(t, KindSliceContainsAll, constraints.KindOf(all))
+	assertEq(t, true, all.IsValid([]int{2, 3, 1}))
+	assertEq(t, false, all.IsValid([]int{2, 3}))
+	assertEq(t, []int{1, 2}, all.(interface{ Elements() []int }).Elements())
+}
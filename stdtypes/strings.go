@@ -5,6 +5,7 @@ package stdtypes
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 	strlib "strings"
 
@@ -16,6 +17,13 @@ var StringSet = constraints.Set[string]
 // StringConstraint is an abstract type for string-related constraints.
 type StringConstraint = constraints.Constraint[string]
 
+// Kinds of the string constraints.
+const (
+	KindStringPrefix  constraints.Kind = "string.prefix"
+	KindStringSuffix  constraints.Kind = "string.suffix"
+	KindStringPattern constraints.Kind = "string.pattern"
+)
+
 // Built-in non-parametric constraints.
 var (
 	StringLength      = Length[string]
@@ -23,17 +31,31 @@ var (
 	StringMinLength   = MinLength[string]
 	StringMaxLength   = MaxLength[string]
 
+	StringRuneLength      = RuneLength[string]
+	StringRuneLengthRange = RuneLengthRange[string]
+	StringRuneMinLength   = RuneMinLength[string]
+	StringRuneMaxLength   = RuneMaxLength[string]
+
+	StringGraphemeLength      = GraphemeLength[string]
+	StringGraphemeLengthRange = GraphemeLengthRange[string]
+	StringGraphemeMinLength   = GraphemeMinLength[string]
+	StringGraphemeMaxLength   = GraphemeMaxLength[string]
+
 	// EmptyString is a constraint where a value is considered valid if it's
 	// an empty string.
 	EmptyString StringConstraint = constraints.Func(
 		"empty",
-		func(v string) bool { return v == "" })
+		func(v string) bool { return v == "" },
+		constraints.WithMessage("string.empty", nil),
+		constraints.WithCost(constraints.CostCheap))
 
 	// NonEmptyString is a constraint where a value is considered valid if it's
 	// not an empty string.
 	NonEmptyString StringConstraint = constraints.Func(
 		"non-empty",
-		func(v string) bool { return v != "" })
+		func(v string) bool { return v != "" },
+		constraints.WithMessage("string.non_empty", nil),
+		constraints.WithCost(constraints.CostCheap))
 
 	// NonBlankString is a constraint that declares a value as valid
 	// if said value contains not just whitespace.
@@ -44,13 +66,30 @@ var (
 		func(v string) bool {
 			return v == "" || strlib.TrimSpace(v) != ""
 		},
+		constraints.WithMessage("string.non_blank", nil),
+		constraints.WithCost(constraints.CostCheap),
 	)
 )
 
+// StringRunesAny creates a Constraint which declares a string as valid
+// if each of its runes satisfies any of constraintSet. If RuneClassOf
+// supports all of constraintSet, the result is like StringRunesIn of
+// their class, which is described as, e.g., "each rune: 0–9, A–Z or
+// '_'", and it has the kind KindStringRunesIn.
+//
+// Its message is "string.runes_any" either way, with the messages of
+// constraintSet as the argument "constraints". The argument "form" is
+// "class" if there's the message of the class as "class", or "list"
+// otherwise.
 func StringRunesAny(constraintSet ...RuneConstraint) StringConstraint {
 	descs := make([]string, 0, len(constraintSet))
+	msgs := make([]constraints.Message, 0, len(constraintSet))
 	for _, ci := range constraintSet {
 		descs = append(descs, ci.ConstraintDescription())
+		msgs = append(msgs, constraints.MessageOf(ci))
+	}
+	if class, ok := RuneClassOf(constraintSet...); ok {
+		return &runesInConstraint{class: class, anyMsgs: msgs}
 	}
 	return constraints.Func(
 		strings.Join(descs, " or "),
@@ -68,29 +107,30 @@ func StringRunesAny(constraintSet ...RuneConstraint) StringConstraint {
 				}
 			}
 			return true
-		})
+		},
+		constraints.WithMessage("string.runes_any",
+			map[string]any{"form": "list", "constraints": msgs}))
 }
 
+// StringRuneAtIndexAny creates a Constraint which declares a string as
+// valid if its rune at index, in runes, satisfies any of constraintSet.
+// A negative index is counted from the end of strings.
+//
+// Deprecated: Use StringRuneAt with constraints.Any, which could be
+// inspected.
 func StringRuneAtIndexAny(index int, constraintSet ...RuneConstraint) StringConstraint {
 	descs := make([]string, 0, len(constraintSet))
+	msgs := make([]constraints.Message, 0, len(constraintSet))
 	for _, ci := range constraintSet {
 		descs = append(descs, ci.ConstraintDescription())
+		msgs = append(msgs, constraints.MessageOf(ci))
 	}
 	return constraints.Func(
 		strings.Join(descs, " or "),
 		func(v string) bool {
-			var r rune
-			if index < 0 {
-				ni := -index
-				if (ni - 1) >= len(v) {
-					return false
-				}
-				r = []rune(v)[len(v)-ni]
-			} else {
-				if index >= len(v) {
-					return false
-				}
-				r = []rune(v)[index]
+			r, ok := runeAt(v, index)
+			if !ok {
+				return false
 			}
 			for _, c := range constraintSet {
 				if c.IsValid(r) {
@@ -98,7 +138,9 @@ func StringRuneAtIndexAny(index int, constraintSet ...RuneConstraint) StringCons
 				}
 			}
 			return false
-		})
+		},
+		constraints.WithMessage("string.rune_at_index_any",
+			map[string]any{"index": index, "constraints": msgs}))
 }
 
 // StringNoConsecutiveRune creates a Constraint which will declare a string
@@ -119,23 +161,46 @@ func StringNoConsecutiveRune(r rune) StringConstraint {
 				}
 			}
 			return true
-		})
+		},
+		constraints.WithMessage("rune.no_consecutive",
+			map[string]any{"rune": r}))
 }
 
 // StringPrefix creates a Constraint which an instance will be declared
 // as valid if its value is prefixed with the specified prefix.
 func StringPrefix(prefix string) StringConstraint {
 	return &targetOperandFuncConstraint[string]{
-		fmt.Sprintf("prefix %q", prefix),
-		prefix,
-		strlib.HasPrefix}
+		kind:    KindStringPrefix,
+		argName: "prefix",
+		desc:    fmt.Sprintf("prefix %q", prefix),
+		operand: prefix,
+		fn:      strlib.HasPrefix}
 }
 
 // StringSuffix creates a Constraint which an instance will be declared
 // as valid if its value is suffixed with the specified suffix.
 func StringSuffix(suffix string) StringConstraint {
 	return &targetOperandFuncConstraint[string]{
-		fmt.Sprintf("suffix %q", suffix),
-		suffix,
-		strlib.HasSuffix}
+		kind:    KindStringSuffix,
+		argName: "suffix",
+		desc:    fmt.Sprintf("suffix %q", suffix),
+		operand: suffix,
+		fn:      strlib.HasSuffix}
+}
+
+// StringPattern creates a Constraint which an instance will be declared
+// as valid if its value matches the regular expression pattern. Note that
+// the pattern is not anchored.
+//
+// The operand of the constraint is the source text of the pattern.
+func StringPattern(pattern *regexp.Regexp) StringConstraint {
+	return &targetOperandFuncConstraint[string]{
+		kind:    KindStringPattern,
+		cost:    constraints.CostExpensive,
+		argName: "pattern",
+		desc:    fmt.Sprintf("match pattern %q", pattern.String()),
+		operand: pattern.String(),
+		fn: func(target, _ string) bool {
+			return pattern.MatchString(target)
+		}}
 }
@@ -1,6 +1,7 @@
 package stdtypes
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/rez-go/constraints"
@@ -311,12 +312,62 @@ func TestStringAny(t *testing.T) {
 		RuneRange('0', '9'),
 		RuneMatch('_'),
 	)
-	assertEq(t, "from 'A' to 'Z' or from 'a' to 'z' or from '0' to '9' or match '_'",
-		constraint.ConstraintDescription())
+	assertEq(t, "each rune: 0–9, A–Z, '_' or a–z", constraint.ConstraintDescription())
+	assertEq(t, KindStringRunesIn, constraints.KindOf(constraint))
+	assertEq(t, "string.runes_any", constraints.CodeOf(constraint))
+	assertEq(t, 4, len(constraints.ParamsOf(constraint)["constraints"].([]constraints.Message)))
+	assertEq(t, "0–9, A–Z, '_' or a–z",
+		constraints.ParamsOf(constraint)["class"].(constraints.Message).Text)
 	assertEq(t, true, constraint.IsValid(""))
 	assertEq(t, true, constraint.IsValid("hello"))
 	assertEq(t, true, constraint.IsValid("HELLO"))
 	assertEq(t, true, constraint.IsValid("heLLO"))
 	assertEq(t, true, constraint.IsValid("HeLLo"))
 	assertEq(t, true, constraint.IsValid("HELLo"))
+	assertEq(t, false, constraint.IsValid("hello world"))
+
+	// Constraints without a RuneClass are listed.
+	constraint = StringRunesAny(RuneRange('a', 'z'), PrintableRune)
+	assertEq(t, "from 'a' to 'z' or printable rune", constraint.ConstraintDescription())
+	assertEq(t, "string.runes_any", constraints.CodeOf(constraint))
+	assertEq(t, true, constraint.IsValid("hello world"))
+	assertEq(t, false, constraint.IsValid("hello\n"))
+}
+
+func TestStringPattern(t *testing.T) {
+	c := StringPattern(regexp.MustCompile(`^[a-z]+$`))
+	assertEq(t, "match pattern \"^[a-z]+$\"", c.ConstraintDescription())
+	assertEq(t, KindStringPattern, constraints.KindOf(c))
+	assertEq(t, "^[a-z]+$", c.(constraints.OperandConstraint[string]).Operand())
+	assertEq(t, true, c.IsValid("hello"))
+	assertEq(t, false, c.IsValid("Hello"))
+}
+
+func TestStringCodes(t *testing.T) {
+	assertEq(t, "length.min", constraints.CodeOf(StringMinLength(6)))
+	assertEq(t, map[string]any{"min": 6, "unit": "bytes"}, constraints.ParamsOf(StringMinLength(6)))
+	assertEq(t, "string.prefix", constraints.CodeOf(StringPrefix("a")))
+	assertEq(t, "string.non_empty", constraints.CodeOf(NonEmptyString))
+	assertEq(t, "rune.no_consecutive", constraints.CodeOf(StringNoConsecutiveRune('_')))
+	assertEq(t, map[string]any{"rune": '_'},
+		constraints.ParamsOf(StringNoConsecutiveRune('_')))
+}
+
+func TestStringValidateWith(t *testing.T) {
+	startsWithLetter := StringPattern(regexp.MustCompile(`^[a-z]`))
+	c := StringSet(
+		constraints.DependsOn(startsWithLetter, NonEmptyString),
+		StringMaxLength(8),
+		NonEmptyString,
+	)
+
+	violated := func(err error) string {
+		return constraints.ViolatedConstraintFromError[string](err).ConstraintDescription()
+	}
+	assertEq(t, "non-empty",
+		violated(constraints.ValidateWith[string]("", c, constraints.OrderByCost())))
+	assertEq(t, `max length 8, match pattern "^[a-z]"`,
+		violated(constraints.ValidateWith[string]("_too_long", c, constraints.OrderByCost())))
+	assertEq(t, constraints.CostCheap, constraints.CostOf[string](NonEmptyString))
+	assertEq(t, constraints.CostExpensive, constraints.CostOf[string](startsWithLetter))
 }
@@ -9,7 +9,7 @@ import (
 	. "github.com/rez-go/constraints"
 )
 
-func ExampleStringUsername() {
+func Example_stringUsername() {
 
 	var (
 		usernameMinLength = StringMinLength(6)
@@ -92,7 +92,7 @@ func TestStringUsername(t *testing.T) {
 	)
 
 	assertEq(t,
-		"length betwen 6 and 32, "+
+		"length between 6 and 32, "+
 			"allowed characters are A to Z (case-insensitive), 0 to 9 and underscore, "+
 			"starts with a letter, ends with anything but underscore, no consecutive '_'",
 		usernameConstraints.ConstraintDescription())
@@ -0,0 +1,359 @@
+package stdtypes
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/rez-go/constraints"
+)
+
+// Kinds of the Unicode script constraints.
+const (
+	KindStringSingleScript constraints.Kind = "string.single_script"
+	KindStringScripts      constraints.Kind = "string.scripts"
+	KindStringNoRunesIn    constraints.Kind = "string.no_runes_in"
+)
+
+// Built-in rune classes of the general categories and properties of
+// Unicode.
+var (
+	LetterRune      = RuneCategory("L")
+	DigitRune       = RuneCategory("Nd")
+	PunctuationRune = RuneCategory("P")
+	SymbolRune      = RuneCategory("S")
+	MarkRune        = RuneCategory("M")
+	ControlRune     = RuneCategory("Cc")
+	// FormatRune contains the invisible formatting characters, e.g.,
+	// the bidi controls and the zero-width joiner, which is used in
+	// emoji sequences.
+	FormatRune = RuneCategory("Cf")
+	// BidiControlRune contains the characters which override the
+	// direction of text, e.g., U+202E RIGHT-TO-LEFT OVERRIDE. They could
+	// make text display differently from its logical order, e.g.,
+	// in "Trojan Source" attacks.
+	BidiControlRune = NewRuneClass().Table(unicode.Bidi_Control)
+	// InvisibleControlRune contains the control and the format
+	// characters, except U+200C ZERO WIDTH NON-JOINER and U+200D ZERO
+	// WIDTH JOINER, which are needed by some scripts and emoji
+	// sequences.
+	InvisibleControlRune = ControlRune.Union(FormatRune).Except(NewRuneClass().Runes("\u200c\u200d"))
+)
+
+// Built-in constraints for user-visible strings, e.g., display names.
+var (
+	// SingleScriptString is a constraint which declares a string as valid
+	// if its runes are in a single script. See StringSingleScript.
+	SingleScriptString = StringSingleScript()
+
+	// NoControlString is a constraint which declares a string as valid if
+	// it contains no control characters, e.g., U+0000 and newlines. These
+	// are only the runes of the category Cc; it allows the format
+	// characters, e.g., U+202E RIGHT-TO-LEFT OVERRIDE. See
+	// NoInvisibleControlString.
+	NoControlString = StringNoRunesIn(ControlRune)
+
+	// NoInvisibleControlString is a constraint which declares a string
+	// as valid if it contains no control or format characters, except
+	// the joiners. See InvisibleControlRune.
+	NoInvisibleControlString = StringNoRunesIn(InvisibleControlRune)
+
+	// NoBidiControlString is a constraint which declares a string as
+	// valid if it contains no bidi controls.
+	NoBidiControlString = StringNoRunesIn(BidiControlRune)
+)
+
+// RuneCategory creates a RuneClass of the Unicode general categories,
+// e.g., "L" for letters, "Lu" for uppercase letters and "Nd" for
+// decimal digits. It panics if any of the categories is unknown.
+//
+// API status: experimental
+func RuneCategory(categories ...string) *RuneClass {
+	rc := NewRuneClass()
+	for _, name := range categories {
+		t, ok := unicode.Categories[name]
+		if !ok {
+			panic("unknown Unicode category " + name)
+		}
+		rc = rc.Table(t)
+	}
+	return rc
+}
+
+// RuneScript creates a RuneClass of the Unicode scripts, e.g., "Latin",
+// "Han" and "Arabic". It panics if any of the scripts is unknown.
+//
+// Note that many runes, e.g., digits, spaces and most punctuation, are
+// in the Common script instead of the scripts which use them. See
+// StringScripts for strings.
+//
+// API status: experimental
+func RuneScript(scripts ...string) *RuneClass {
+	rc := NewRuneClass()
+	for _, name := range scripts {
+		t, ok := unicode.Scripts[name]
+		if !ok {
+			panic("unknown Unicode script " + name)
+		}
+		rc = rc.Table(t)
+	}
+	return rc
+}
+
+// StringNoRunesIn creates a Constraint which declares a string as valid
+// if none of its runes is in class, e.g.,
+//
+//	StringNoRunesIn(ControlRune.Union(BidiControlRune))
+//
+// is described as "no runes: control or bidi control".
+//
+// API status: experimental
+func StringNoRunesIn(class *RuneClass) StringConstraint {
+	return &noRunesInConstraint{class: class}
+}
+
+type noRunesInConstraint struct {
+	class *RuneClass
+}
+
+var (
+	_ RuneClassConstraint           = &noRunesInConstraint{}
+	_ constraints.KindedConstraint  = &noRunesInConstraint{}
+	_ constraints.MessageConstraint = &noRunesInConstraint{}
+)
+
+// ConstraintDescription conforms constraints.Constraint interface.
+func (c *noRunesInConstraint) ConstraintDescription() string {
+	return "no runes: " + c.class.ConstraintDescription()
+}
+
+// ConstraintKind conforms constraints.KindedConstraint interface.
+func (c *noRunesInConstraint) ConstraintKind() constraints.Kind {
+	return KindStringNoRunesIn
+}
+
+// ConstraintMessage conforms constraints.MessageConstraint interface.
+func (c *noRunesInConstraint) ConstraintMessage() constraints.Message {
+	return constraints.Message{
+		ID:   string(KindStringNoRunesIn),
+		Args: map[string]any{"class": c.class.ConstraintMessage()},
+		Text: c.ConstraintDescription(),
+	}
+}
+
+// RuneClass conforms RuneClassConstraint interface. It returns the
+// class of the disallowed runes.
+func (c *noRunesInConstraint) RuneClass() *RuneClass {
+	return c.class
+}
+
+// IsValid conforms constraints.Constraint interface.
+func (c *noRunesInConstraint) IsValid(v string) bool {
+	for _, r := range v {
+		if c.class.Contains(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// StringSingleScript creates a Constraint which declares a string as
+// valid if all of its runes are in a single script, ignoring the runes
+// of the Common and Inherited scripts, e.g., digits and combining marks.
+// It helps to prevent spoofing with look-alike runes of other scripts,
+// e.g., the Cyrillic "а" (U+0430) in "pаypal".
+//
+// Like the single-script resolution of Unicode Technical Standard #39,
+// Han is allowed with Hiragana and Katakana for Japanese, with Hangul
+// for Korean, and with Bopomofo for Chinese. As package unicode doesn't
+// have the Script_Extensions property, the runes which are shared by
+// some scripts are considered to be of their primary scripts.
+//
+// API status: experimental
+func StringSingleScript() StringConstraint {
+	return &scriptsConstraint{kind: KindStringSingleScript}
+}
+
+// StringScripts creates a Constraint which declares a string as valid if
+// all of its runes are in the specified scripts, or in the Common and
+// Inherited scripts, e.g., digits, spaces and combining marks. It panics
+// if any of the scripts is unknown.
+//
+// API status: experimental
+func StringScripts(scripts ...string) StringConstraint {
+	tables := make([]*unicode.RangeTable, 0, len(scripts))
+	for _, name := range scripts {
+		t, ok := unicode.Scripts[name]
+		if !ok {
+			panic("unknown Unicode script " + name)
+		}
+		tables = append(tables, t)
+	}
+	names := make([]string, len(scripts))
+	copy(names, scripts)
+	return &scriptsConstraint{kind: KindStringScripts, scripts: names, tables: tables}
+}
+
+type scriptsConstraint struct {
+	kind    constraints.Kind
+	scripts []string
+	tables  []*unicode.RangeTable
+}
+
+var (
+	_ StringConstraint              = &scriptsConstraint{}
+	_ constraints.KindedConstraint  = &scriptsConstraint{}
+	_ constraints.MessageConstraint = &scriptsConstraint{}
+)
+
+// ConstraintDescription conforms constraints.Constraint interface.
+func (c *scriptsConstraint) ConstraintDescription() string {
+	if c.kind == KindStringSingleScript {
+		return "single script"
+	}
+	return "scripts: " + strings.Join(c.scripts, ", ")
+}
+
+// ConstraintKind conforms constraints.KindedConstraint interface.
+func (c *scriptsConstraint) ConstraintKind() constraints.Kind {
+	return c.kind
+}
+
+// ConstraintMessage conforms constraints.MessageConstraint interface.
+func (c *scriptsConstraint) ConstraintMessage() constraints.Message {
+	var args map[string]any
+	if c.kind == KindStringScripts {
+		scripts := make([]any, 0, len(c.scripts))
+		for _, s := range c.scripts {
+			scripts = append(scripts, s)
+		}
+		args = map[string]any{"scripts": scripts}
+	}
+	return constraints.Message{ID: string(c.kind), Args: args, Text: c.ConstraintDescription()}
+}
+
+// Scripts returns the names of the allowed scripts. It's empty for
+// StringSingleScript.
+func (c *scriptsConstraint) Scripts() []string {
+	scripts := make([]string, len(c.scripts))
+	copy(scripts, c.scripts)
+	return scripts
+}
+
+// IsValid conforms constraints.Constraint interface.
+func (c *scriptsConstraint) IsValid(v string) bool {
+	if c.kind == KindStringSingleScript {
+		return isSingleScript(v)
+	}
+	for _, r := range v {
+		if unicode.In(r, unicode.Common, unicode.Inherited) {
+			continue
+		}
+		if !unicode.In(r, c.tables...) {
+			return false
+		}
+	}
+	return true
+}
+
+// ScriptsOf returns the sorted names of the scripts of the runes of v,
+// except Common and Inherited, e.g., ["Cyrillic", "Latin"] for
+// "pаypal" with the Cyrillic "а" (U+0430). Unassigned runes are ignored.
+//
+// API status: experimental
+func ScriptsOf(v string) []string {
+	var scripts []string
+	var last *unicode.RangeTable
+	for _, r := range v {
+		if last != nil && unicode.Is(last, r) {
+			continue
+		}
+		name, t := scriptOf(r)
+		if t == nil || t == unicode.Common || t == unicode.Inherited {
+			continue
+		}
+		last = t
+		i := sort.SearchStrings(scripts, name)
+		if i < len(scripts) && scripts[i] == name {
+			continue
+		}
+		scripts = append(scripts, "")
+		copy(scripts[i+1:], scripts[i:])
+		scripts[i] = name
+	}
+	return scripts
+}
+
+// The sets of writing systems of the scripts which are used together,
+// as the augmented script sets of Unicode Technical Standard #39.
+const (
+	scriptSetHani = 1 << iota
+	scriptSetJpan
+	scriptSetKore
+	scriptSetHanb
+)
+
+var scriptSets = map[string]uint8{
+	"Han":      scriptSetHani | scriptSetJpan | scriptSetKore | scriptSetHanb,
+	"Hiragana": scriptSetJpan,
+	"Katakana": scriptSetJpan,
+	"Hangul":   scriptSetKore,
+	"Bopomofo": scriptSetHanb,
+}
+
+func isSingleScript(v string) bool {
+	var script string
+	var last *unicode.RangeTable
+	// sets is the intersection of the sets of the CJK scripts.
+	var sets uint8
+	for _, r := range v {
+		if last != nil && unicode.Is(last, r) {
+			continue
+		}
+		name, t := scriptOf(r)
+		if t == nil || t == unicode.Common || t == unicode.Inherited {
+			continue
+		}
+		last = t
+		if set, ok := scriptSets[name]; ok {
+			if script == "" {
+				script, sets = "CJK", set
+				continue
+			}
+			sets &= set
+			if script != "CJK" || sets == 0 {
+				return false
+			}
+			continue
+		}
+		if script == "" {
+			script = name
+		} else if script != name {
+			return false
+		}
+	}
+	return true
+}
+
+// scriptNames are the names of the scripts in package unicode, with
+// Common and Inherited first as they're the most frequent.
+var scriptNames = func() []string {
+	names := []string{"Common", "Inherited", "Latin"}
+	for _, n := range sortedTableNames(unicode.Scripts) {
+		if n != "Common" && n != "Inherited" && n != "Latin" {
+			names = append(names, n)
+		}
+	}
+	return names
+}()
+
+// scriptOf returns the script of r, or nil if r is not assigned to any
+// script.
+func scriptOf(r rune) (string, *unicode.RangeTable) {
+	for _, n := range scriptNames {
+		if t := unicode.Scripts[n]; unicode.Is(t, r) {
+			return n, t
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,132 @@
+package stdtypes
+
+import (
+	"unicode/utf8"
+
+	"github.com/rez-go/constraints"
+)
+
+// Kinds of the length constraints which count runes.
+const (
+	KindRuneLength      constraints.Kind = "rune_length"
+	KindRuneLengthMin   constraints.Kind = "rune_length.min"
+	KindRuneLengthMax   constraints.Kind = "rune_length.max"
+	KindRuneLengthRange constraints.Kind = "rune_length.range"
+)
+
+// Kinds of the length constraints which count grapheme clusters.
+const (
+	KindGraphemeLength      constraints.Kind = "grapheme_length"
+	KindGraphemeLengthMin   constraints.Kind = "grapheme_length.min"
+	KindGraphemeLengthMax   constraints.Kind = "grapheme_length.max"
+	KindGraphemeLengthRange constraints.Kind = "grapheme_length.range"
+)
+
+// RuneLength returns a Constraint that will declare a value as valid
+// if its number of Unicode code points is exactly as specified.
+//
+// Invalid UTF-8 bytes are counted as one rune each.
+//
+// API status: experimental
+func RuneLength[ValueT lenable](specifiedLength int) constraints.Constraint[ValueT] {
+	if specifiedLength < 0 {
+		panic("specifiedLength must be zero or a positive integer")
+	}
+	return &lengthConstraint[ValueT]{min: specifiedLength, max: specifiedLength, unit: LengthUnitRunes, length: runeLength[ValueT]}
+}
+
+// RuneMaxLength returns a Constraint that will declare a value as
+// valid if its number of Unicode code points is at most maxLength.
+//
+// API status: experimental
+func RuneMaxLength[ValueT lenable](maxLength int) constraints.Constraint[ValueT] {
+	if maxLength < 0 {
+		panic("maxLength must be zero or a positive integer")
+	}
+	return &lengthConstraint[ValueT]{min: -1, max: maxLength, unit: LengthUnitRunes, length: runeLength[ValueT]}
+}
+
+// RuneMinLength returns a Constraint that will declare a value as
+// valid if its number of Unicode code points is at least minLength.
+//
+// API status: experimental
+func RuneMinLength[ValueT lenable](minLength int) constraints.Constraint[ValueT] {
+	if minLength < 0 {
+		panic("minLength must be zero or a positive integer")
+	}
+	return &lengthConstraint[ValueT]{min: minLength, max: -1, unit: LengthUnitRunes, length: runeLength[ValueT]}
+}
+
+// RuneLengthRange returns a Constraint that will declare a value as
+// valid if its number of Unicode code points is between min and max,
+// inclusive.
+//
+// API status: experimental
+func RuneLengthRange[ValueT lenable](min, max int) constraints.Constraint[ValueT] {
+	return &lengthConstraint[ValueT]{min: min, max: max, unit: LengthUnitRunes, length: runeLength[ValueT]}
+}
+
+func runeLength[ValueT lenable](v ValueT) int {
+	switch tv := any(v).(type) {
+	case string:
+		return utf8.RuneCountInString(tv)
+	case []byte:
+		return utf8.RuneCount(tv)
+	}
+	return utf8.RuneCountInString(string(v))
+}
+
+// GraphemeLength returns a Constraint that will declare a value as
+// valid if its number of extended grapheme clusters, i.e.,
+// user-perceived characters, is exactly as specified. For example,
+// "é" and the flag "🇯🇵" are one grapheme each.
+//
+// The grapheme clusters are determined with the rules of Unicode
+// Standard Annex #29 on the tables of package unicode. As the package
+// doesn't have the Extended_Pictographic property, emoji are
+// recognized by their blocks.
+//
+// API status: experimental
+func GraphemeLength[ValueT lenable](specifiedLength int) constraints.Constraint[ValueT] {
+	if specifiedLength < 0 {
+		panic("specifiedLength must be zero or a positive integer")
+	}
+	return &lengthConstraint[ValueT]{min: specifiedLength, max: specifiedLength, unit: LengthUnitGraphemes, length: graphemeLength[ValueT]}
+}
+
+// GraphemeMaxLength returns a Constraint that will declare a value as
+// valid if its number of extended grapheme clusters is at most
+// maxLength. See GraphemeLength.
+//
+// API status: experimental
+func GraphemeMaxLength[ValueT lenable](maxLength int) constraints.Constraint[ValueT] {
+	if maxLength < 0 {
+		panic("maxLength must be zero or a positive integer")
+	}
+	return &lengthConstraint[ValueT]{min: -1, max: maxLength, unit: LengthUnitGraphemes, length: graphemeLength[ValueT]}
+}
+
+// GraphemeMinLength returns a Constraint that will declare a value as
+// valid if its number of extended grapheme clusters is at least
+// minLength. See GraphemeLength.
+//
+// API status: experimental
+func GraphemeMinLength[ValueT lenable](minLength int) constraints.Constraint[ValueT] {
+	if minLength < 0 {
+		panic("minLength must be zero or a positive integer")
+	}
+	return &lengthConstraint[ValueT]{min: minLength, max: -1, unit: LengthUnitGraphemes, length: graphemeLength[ValueT]}
+}
+
+// GraphemeLengthRange returns a Constraint that will declare a value
+// as valid if its number of extended grapheme clusters is between min
+// and max, inclusive. See GraphemeLength.
+//
+// API status: experimental
+func GraphemeLengthRange[ValueT lenable](min, max int) constraints.Constraint[ValueT] {
+	return &lengthConstraint[ValueT]{min: min, max: max, unit: LengthUnitGraphemes, length: graphemeLength[ValueT]}
+}
+
+func graphemeLength[ValueT lenable](v ValueT) int {
+	return graphemeCount(string(v))
+}
@@ -0,0 +1,80 @@
+package stdtypes
+
+import (
+	"testing"
+
+	"github.com/rez-go/constraints"
+)
+
+func TestRuneLength(t *testing.T) {
+	username := "さくらやまだ"
+	assertEq(t, false, StringMaxLength(6).IsValid(username))
+	assertEq(t, true, StringRuneMaxLength(6).IsValid(username))
+	assertEq(t, true, StringRuneLength(6).IsValid(username))
+	assertEq(t, false, StringRuneMinLength(7).IsValid(username))
+	assertEq(t, true, RuneLengthRange[[]byte](1, 2).IsValid([]byte("日本")))
+	// An invalid byte is one rune.
+	assertEq(t, true, StringRuneLength(2).IsValid("a\xff"))
+}
+
+func TestGraphemeLength(t *testing.T) {
+	cases := []struct {
+		s     string
+		count int
+	}{
+		{"", 0},
+		{"abc", 3},
+		{"\u00e9", 1},
+		{"e\u0301", 1},
+		{"\r\n", 1},
+		{"\n\r", 2},
+		{"🇯🇵🇮🇩", 2},
+		{"🇯🇵🇮", 2},
+		{"👍🏽", 1},
+		{"👩‍💻", 1},
+		{"👨‍👩‍👧‍👦", 1},
+		{"a\u200db", 2},
+		{"한국어", 3},
+		{"각", 1},
+		// GB9c: a consonant, a virama and a consonant are one cluster.
+		{"नमस्ते", 3},
+		{"क्‍ष", 1},
+		{"ক্ষ", 1},
+		{"क्a", 2},
+		{"ab्क", 3},
+		{"؀١", 1},
+		{"❤️", 1},
+	}
+	for _, c := range cases {
+		assertEq(t, c.count, graphemeCount(c.s), "%q", c.s)
+	}
+
+	assertEq(t, true, StringGraphemeLength(1).IsValid("🇯🇵"))
+	assertEq(t, false, StringRuneLength(1).IsValid("🇯🇵"))
+	assertEq(t, true, StringGraphemeMaxLength(2).IsValid("e\u0301e\u0301"))
+	assertEq(t, false, StringGraphemeMinLength(3).IsValid("e\u0301e\u0301"))
+}
+
+func TestLengthUnit(t *testing.T) {
+	cases := []struct {
+		constraint  constraints.ConstraintBase
+		unit        LengthUnit
+		kind        constraints.Kind
+		description string
+	}{
+		{StringMinLength(6), LengthUnitBytes, KindLengthMin, "min length 6"},
+		{StringRuneLength(5), LengthUnitRunes, KindRuneLength, "rune length 5"},
+		{StringRuneMaxLength(32), LengthUnitRunes, KindRuneLengthMax, "max rune length 32"},
+		{StringGraphemeMinLength(6), LengthUnitGraphemes, KindGraphemeLengthMin, "min grapheme length 6"},
+		{StringGraphemeLengthRange(6, 32), LengthUnitGraphemes, KindGraphemeLengthRange,
+			"grapheme length between 6 and 32"},
+		{SliceMaxLength[[]int](3), LengthUnitElements, KindLengthMax, "max length 3"},
+	}
+	for _, c := range cases {
+		assertEq(t, c.unit, c.constraint.(LengthUnitConstraint).LengthUnit())
+		assertEq(t, c.kind, constraints.KindOf(c.constraint))
+		assertEq(t, c.description, c.constraint.ConstraintDescription())
+	}
+	assertEq(t, map[string]any{"min": 6, "max": 32, "unit": "runes"},
+		constraints.ParamsOf(StringRuneLengthRange(6, 32)))
+}
@@ -0,0 +1,88 @@
+package stdtypes
+
+import (
+	"testing"
+
+	"github.com/rez-go/constraints"
+)
+
+func TestRuneCategory(t *testing.T) {
+	assertEq(t, "letter", LetterRune.ConstraintDescription())
+	assertEq(t, true, LetterRune.IsValid('ß'))
+	assertEq(t, true, LetterRune.IsValid('漢'))
+	assertEq(t, false, LetterRune.IsValid('1'))
+	assertEq(t, true, DigitRune.IsValid('٣'))
+	assertEq(t, true, PunctuationRune.IsValid('、'))
+	assertEq(t, true, SymbolRune.IsValid('€'))
+	assertEq(t, true, MarkRune.IsValid('\u0301'))
+
+	c := RuneCategory("Lu", "Nd")
+	assertEq(t, "uppercase letter or decimal number", c.ConstraintDescription())
+	assertEq(t, `[\p{Lu}\p{Nd}]`, c.Regexp())
+	assertEq(t, true, c.IsValid('A'))
+	assertEq(t, false, c.IsValid('a'))
+
+	assertEq(t, "Latin script or Han script", RuneScript("Latin", "Han").ConstraintDescription())
+	assertEq(t, true, RuneScript("Arabic").IsValid('ع'))
+}
+
+func TestStringNoRunesIn(t *testing.T) {
+	assertEq(t, "no runes: bidi control", NoBidiControlString.ConstraintDescription())
+	assertEq(t, KindStringNoRunesIn, constraints.KindOf(NoBidiControlString))
+	assertEq(t, true, NoBidiControlString.IsValid("access_level"))
+	// A "Trojan Source" comment with RLO and LRI.
+	assertEq(t, false, NoBidiControlString.IsValid("/*\u202e } \u2066if (isAdmin)\u2069 \u2066 begin admins only */"))
+	assertEq(t, true, NoBidiControlString.IsValid("👩\u200d💻"))
+
+	assertEq(t, false, NoControlString.IsValid("line\nbreak"))
+	// Format characters are not control characters.
+	assertEq(t, true, NoControlString.IsValid("\u202e"))
+
+	assertEq(t, "no runes: control or format except U+200C or U+200D",
+		NoInvisibleControlString.ConstraintDescription())
+	assertEq(t, false, NoInvisibleControlString.IsValid("line\nbreak"))
+	assertEq(t, false, NoInvisibleControlString.IsValid("\u202e"))
+	assertEq(t, false, NoInvisibleControlString.IsValid("zero\u200bwidth"))
+	assertEq(t, true, NoInvisibleControlString.IsValid("👩\u200d💻"))
+	assertEq(t, true, NoInvisibleControlString.IsValid("می\u200cخواهم"))
+
+	c := StringNoRunesIn(ControlRune.Union(FormatRune))
+	assertEq(t, "no runes: control or format", c.ConstraintDescription())
+	assertEq(t, false, c.IsValid("\u202e"))
+	assertEq(t, false, c.IsValid("zero\u200bwidth"))
+}
+
+func TestStringSingleScript(t *testing.T) {
+	cases := []struct {
+		v       string
+		valid   bool
+		scripts []string
+	}{
+		{"", true, nil},
+		{"123 !", true, nil},
+		{"paypal", true, []string{"Latin"}},
+		{"p\u0430ypal", false, []string{"Cyrillic", "Latin"}},
+		{"Ελληνικά 2024", true, []string{"Greek"}},
+		{"東京タワー", true, []string{"Han", "Katakana"}},
+		{"ひらがなとカタカナと漢字", true, []string{"Han", "Hiragana", "Katakana"}},
+		{"한국어 漢字", true, []string{"Han", "Hangul"}},
+		{"ひらがな한국어", false, []string{"Hangul", "Hiragana"}},
+		{"漢字abc", false, []string{"Han", "Latin"}},
+		{"cafe\u0301", true, []string{"Latin"}},
+	}
+	for _, c := range cases {
+		assertEq(t, c.valid, SingleScriptString.IsValid(c.v), "%q", c.v)
+		assertEq(t, c.scripts, ScriptsOf(c.v), "%q", c.v)
+	}
+	assertEq(t, "single script", SingleScriptString.ConstraintDescription())
+	assertEq(t, KindStringSingleScript, constraints.KindOf(SingleScriptString))
+}
+
+func TestStringScripts(t *testing.T) {
+	c := StringScripts("Latin", "Han")
+	assertEq(t, "scripts: Latin, Han", c.ConstraintDescription())
+	assertEq(t, KindStringScripts, constraints.KindOf(c))
+	assertEq(t, true, c.IsValid("Tokyo 東京, 2024!"))
+	assertEq(t, false, c.IsValid("Tōkyō とうきょう"))
+	assertEq(t, []string{"Latin", "Han"}, c.(interface{ Scripts() []string }).Scripts())
+}
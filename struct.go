@@ -0,0 +1,206 @@
+package constraints
+
+import "strings"
+
+// A FieldConstraint is a constraint of a struct which validates one of
+// its fields. It's created with Field.
+//
+// API status: experimental
+type FieldConstraint[StructT any] interface {
+	Constraint[StructT]
+
+	// FieldName returns the name of the field, e.g., "username".
+	FieldName() string
+
+	// FieldValueConstraint returns the constraint of the field value.
+	// It returns nil for constraints which depend on more than one
+	// field, e.g., FieldsEqual.
+	FieldValueConstraint() ConstraintBase
+
+	// FieldError validates the field of v. It returns nil if the field
+	// value is valid. Otherwise, it returns an ErrorList which contains
+	// the errors of the field and its nested fields, if any.
+	FieldError(v StructT) error
+}
+
+// Field creates a FieldConstraint named name which validates the value
+// projected from a struct by get against constraint c.
+//
+//	usernameField := Field("username",
+//		func(u User) string { return u.Username },
+//		usernameConstraints)
+func Field[StructT, FieldT any](
+	name string,
+	get func(v StructT) FieldT,
+	c Constraint[FieldT],
+) FieldConstraint[StructT] {
+	return &fieldConstraint[StructT, FieldT]{name: name, get: get, c: c}
+}
+
+type fieldConstraint[StructT, FieldT any] struct {
+	name string
+	get  func(v StructT) FieldT
+	c    Constraint[FieldT]
+}
+
+var (
+	_ FieldConstraint[struct{}] = &fieldConstraint[struct{}, int]{}
+	_ KindedConstraint          = &fieldConstraint[struct{}, int]{}
+	_ MessageConstraint         = &fieldConstraint[struct{}, int]{}
+)
+
+// ConstraintDescription conforms Constraint interface.
+func (fc *fieldConstraint[StructT, FieldT]) ConstraintDescription() string {
+	return fc.name + ": " + fc.c.ConstraintDescription()
+}
+
+// ConstraintKind conforms KindedConstraint interface.
+func (fc *fieldConstraint[StructT, FieldT]) ConstraintKind() Kind {
+	return KindField
+}
+
+// ConstraintMessage conforms MessageConstraint interface.
+func (fc *fieldConstraint[StructT, FieldT]) ConstraintMessage() Message {
+	return Message{
+		ID:   string(KindField),
+		Args: map[string]any{"field": fc.name, "constraint": MessageOf(fc.c)},
+		Text: fc.ConstraintDescription(),
+	}
+}
+
+// IsValid conforms Constraint interface.
+func (fc *fieldConstraint[StructT, FieldT]) IsValid(v StructT) bool {
+	return fc.c.IsValid(fc.get(v))
+}
+
+// FieldName conforms FieldConstraint interface.
+func (fc *fieldConstraint[StructT, FieldT]) FieldName() string {
+	return fc.name
+}
+
+// FieldValueConstraint conforms FieldConstraint interface.
+func (fc *fieldConstraint[StructT, FieldT]) FieldValueConstraint() ConstraintBase {
+	return fc.c
+}
+
+// FieldError conforms FieldConstraint interface.
+func (fc *fieldConstraint[StructT, FieldT]) FieldError(v StructT) error {
+	fv := fc.get(v)
+	var errs ErrorList
+	ValidateField(&errs, Path(fc.name), fv, fc.c)
+	return errs.Err()
+}
+
+// Struct creates a StructConstraint which validates values of type
+// StructT field by field, without reflection.
+//
+//	var userConstraints = Struct(
+//		Field("username", func(u User) string { return u.Username },
+//			usernameConstraints),
+//		Field("age", func(u User) int { return u.Age },
+//			Range(13, 130)))
+//
+// API status: experimental
+func Struct[StructT any](fields ...FieldConstraint[StructT]) *StructConstraint[StructT] {
+	copies := make([]FieldConstraint[StructT], len(fields))
+	copy(copies, fields)
+	return &StructConstraint[StructT]{fields: copies}
+}
+
+// A StructConstraint is a constraint which consisted of field
+// constraints. A value is considered valid if all of its fields are
+// valid.
+type StructConstraint[StructT any] struct {
+	fields []FieldConstraint[StructT]
+}
+
+var (
+	_ ConstraintSet[struct{}, FieldConstraint[struct{}]] = &StructConstraint[struct{}]{}
+	_ CompositeConstraint[struct{}]                      = &StructConstraint[struct{}]{}
+	_ KindedConstraint                                   = &StructConstraint[struct{}]{}
+	_ MessageConstraint                                  = &StructConstraint[struct{}]{}
+)
+
+// With returns a new StructConstraint with fields added.
+func (sc *StructConstraint[StructT]) With(fields ...FieldConstraint[StructT]) *StructConstraint[StructT] {
+	copies := make([]FieldConstraint[StructT], 0, len(sc.fields)+len(fields))
+	copies = append(copies, sc.fields...)
+	copies = append(copies, fields...)
+	return &StructConstraint[StructT]{fields: copies}
+}
+
+// ConstraintDescription conforms Constraint interface.
+func (sc *StructConstraint[StructT]) ConstraintDescription() string {
+	descs := make([]string, 0, len(sc.fields))
+	for _, fc := range sc.fields {
+		descs = append(descs, fc.ConstraintDescription())
+	}
+	return strings.Join(descs, "; ")
+}
+
+// ConstraintKind conforms KindedConstraint interface.
+func (sc *StructConstraint[StructT]) ConstraintKind() Kind {
+	return KindStruct
+}
+
+// ConstraintMessage conforms MessageConstraint interface.
+func (sc *StructConstraint[StructT]) ConstraintMessage() Message {
+	return Message{
+		ID:   string(KindStruct),
+		Args: map[string]any{"fields": messageList(sc.Children())},
+		Text: sc.ConstraintDescription(),
+	}
+}
+
+// ConstraintList conforms ConstraintSet interface.
+func (sc *StructConstraint[StructT]) ConstraintList() []FieldConstraint[StructT] {
+	copies := make([]FieldConstraint[StructT], len(sc.fields))
+	copy(copies, sc.fields)
+	return copies
+}
+
+// Children conforms CompositeConstraint interface.
+func (sc *StructConstraint[StructT]) Children() []Constraint[StructT] {
+	children := make([]Constraint[StructT], 0, len(sc.fields))
+	for _, fc := range sc.fields {
+		children = append(children, fc)
+	}
+	return children
+}
+
+// IsValid conforms Constraint interface.
+func (sc *StructConstraint[StructT]) IsValid(v StructT) bool {
+	return sc.Validate(v) == nil
+}
+
+// Validate returns the first violated field constraint, or nil if
+// the value is valid.
+func (sc *StructConstraint[StructT]) Validate(v StructT) Constraint[StructT] {
+	for _, fc := range sc.fields {
+		if !fc.IsValid(v) {
+			return fc
+		}
+	}
+	return nil
+}
+
+// ValidateAll returns all the violated field constraints.
+func (sc *StructConstraint[StructT]) ValidateAll(v StructT) (violated []Constraint[StructT]) {
+	for _, fc := range sc.fields {
+		if !fc.IsValid(v) {
+			violated = append(violated, fc)
+		}
+	}
+	return violated
+}
+
+// FieldErrors validates all the fields of v. The result contains
+// an error for each invalid field, with its path, e.g., "username" or
+// "address.zip" for nested structs.
+func (sc *StructConstraint[StructT]) FieldErrors(v StructT) ErrorList {
+	var errs ErrorList
+	for _, fc := range sc.fields {
+		errs.Add(nil, fc.FieldError(v))
+	}
+	return errs
+}
@@ -0,0 +1,73 @@
+package constraints
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+type testAddress struct {
+	Zip string
+}
+
+type testUser struct {
+	Username string
+	Age      int
+	Address  testAddress
+}
+
+var testUserConstraints = Struct(
+	Field[testUser, string]("username",
+		func(u testUser) string { return u.Username },
+		NoneOf("", "root")),
+	Field[testUser, int]("age",
+		func(u testUser) int { return u.Age },
+		Range(13, 130)),
+	Field[testUser, testAddress]("address",
+		func(u testUser) testAddress { return u.Address },
+		Struct(Field[testAddress, string]("zip",
+			func(a testAddress) string { return a.Zip },
+			Negate[string](Match(""), "non-empty")))),
+)
+
+func TestStruct(t *testing.T) {
+	assertEq(t, `username: none of [, root]; age: from 13 to 130; address: zip: non-empty`,
+		testUserConstraints.ConstraintDescription())
+
+	valid := testUser{Username: "alice", Age: 20, Address: testAddress{Zip: "12345"}}
+	assertEq(t, true, testUserConstraints.IsValid(valid))
+	assertEq(t, 0, len(testUserConstraints.ValidateAll(valid)))
+	assertEq(t, nil, testUserConstraints.FieldErrors(valid).Err())
+
+	invalid := testUser{Username: "root", Age: 20}
+	violated := testUserConstraints.ValidateAll(invalid)
+	assertEq(t, 2, len(violated))
+	assertEq(t, "username", violated[0].(FieldConstraint[testUser]).FieldName())
+	assertEq(t, "address", violated[1].(FieldConstraint[testUser]).FieldName())
+	assertEq(t, true, ValidOrError[testUser](invalid, testUserConstraints) != nil)
+
+	errs := testUserConstraints.FieldErrors(invalid)
+	assertEq(t, `username: required to be none of [, root]; address.zip: required to be non-empty`,
+		errs.Error())
+	var fe *FieldError
+	assertEq(t, true, errors.As(errs.Err(), &fe))
+	assertEq(t, "username", fe.Path.String())
+
+	b, _ := json.Marshal(errs)
+	assertEq(t, `[{"field":"username","pointer":"/username","code":"none_of",`+
+		`"params":{"options":["","root"]},"description":"none of [, root]"},`+
+		`{"field":"address.zip","pointer":"/address/zip","params":{"constraint":`+
+		`{"code":"match","description":"match \"\"","params":{"value":""}}},`+
+		`"description":"non-empty"}]`,
+		string(b))
+}
+
+func TestStructWith(t *testing.T) {
+	base := Struct[testUser]()
+	extended := base.With(Field[testUser, int]("age",
+		func(u testUser) int { return u.Age }, Min(18)))
+	assertEq(t, 0, len(base.ConstraintList()))
+	assertEq(t, 1, len(extended.ConstraintList()))
+	assertEq(t, false, extended.IsValid(testUser{Age: 17}))
+	assertEq(t, KindStruct, KindOf(extended))
+}
@@ -0,0 +1,400 @@
+// Package structtag validates structs based on their field tags, e.g.,
+//
+//	type SignUpRequest struct {
+//		Username string `json:"username" constraint:"username"`
+//		Password string `json:"password" constraint:"minlen=8,maxlen=64"`
+//		Role     string `json:"role" constraint:"oneof=admin|member"`
+//		Age      int    `json:"age" constraint:"range=13..130"`
+//	}
+//
+// A tag is a comma-separated list of constraints. The built-in
+// constraints are:
+//
+//   - required: the value is not the zero value
+//   - len=N, minlen=N, maxlen=N: the length of a string in runes, i.e.,
+//     Unicode code points, like minLength and maxLength of JSON Schema
+//   - min=N, max=N, range=N..M: the bounds of a number
+//   - oneof=a|b|c, noneof=a|b|c: the choices of a string or a number
+//   - prefix=s, suffix=s: the prefix or the suffix of a string
+//
+// Other names refer to constraints registered with Register, e.g.,
+// "username" in the example above.
+//
+// Fields of struct type, and slices of structs, are validated
+// recursively. The field paths in the errors use the names from the
+// json tags if present.
+//
+// API status: experimental
+package structtag
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/rez-go/constraints"
+	"github.com/rez-go/constraints/stdtypes"
+)
+
+// TagName is the name of the struct tag read by the validator.
+const TagName = "constraint"
+
+// A check validates a field value. It returns a constraint violation
+// error, or nil if the value is valid.
+type check func(v reflect.Value) error
+
+// A Registry holds the named constraints which could be referred to in
+// tags. A Registry is safe for concurrent use.
+type Registry struct {
+	mu    sync.RWMutex
+	named map[string]namedConstraint
+	plans map[reflect.Type]*structPlan
+}
+
+type namedConstraint struct {
+	valueType reflect.Type
+	check     check
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		named: map[string]namedConstraint{},
+		plans: map[reflect.Type]*structPlan{},
+	}
+}
+
+// DefaultRegistry is the Registry used by the package-level functions.
+var DefaultRegistry = NewRegistry()
+
+// Register registers constraint c as name in registry r. The constraint
+// could only be applied to fields whose type is ValueT or is defined on
+// the same underlying type, e.g., a named string type for
+// a Constraint[string].
+func Register[ValueT any](r *Registry, name string, c constraints.Constraint[ValueT]) {
+	t := reflect.TypeOf((*ValueT)(nil)).Elem()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.named[name] = namedConstraint{
+		valueType: t,
+		check:     typedCheck(c),
+	}
+	// Plans might refer to the previous constraint of the same name.
+	r.plans = map[reflect.Type]*structPlan{}
+}
+
+// Validate validates struct v, or a pointer to a struct, against
+// the constraints declared in its tags using DefaultRegistry.
+func Validate(v any) error {
+	return DefaultRegistry.Validate(v)
+}
+
+// Validate validates struct v, or a pointer to a struct, against
+// the constraints declared in its tags. If any field is invalid, it
+// returns a constraints.ErrorList. It returns an error of other type if
+// a tag is malformed or refers to an unknown constraint.
+func (r *Registry) Validate(v any) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("structtag: expecting a struct, got %s", rv.Type())
+	}
+	var errs constraints.ErrorList
+	if err := r.validateStruct(&errs, nil, rv); err != nil {
+		return err
+	}
+	return errs.Err()
+}
+
+func (r *Registry) validateStruct(errs *constraints.ErrorList, path constraints.FieldPath, v reflect.Value) error {
+	plan, err := r.plan(v.Type())
+	if err != nil {
+		return err
+	}
+	for _, f := range plan.fields {
+		fv := v.Field(f.index)
+		fpath := path.Field(f.name)
+		for _, c := range f.checks {
+			errs.Add(fpath, c(fv))
+		}
+		if err := r.validateNested(errs, fpath, fv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Registry) validateNested(errs *constraints.ErrorList, path constraints.FieldPath, v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			return r.validateNested(errs, path, v.Elem())
+		}
+	case reflect.Struct:
+		return r.validateStruct(errs, path, v)
+	case reflect.Slice, reflect.Array:
+		elemKind := v.Type().Elem().Kind()
+		if elemKind == reflect.Struct || elemKind == reflect.Pointer {
+			for i := 0; i < v.Len(); i++ {
+				if err := r.validateNested(errs, path.Index(i), v.Index(i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+type structPlan struct {
+	fields []fieldPlan
+}
+
+type fieldPlan struct {
+	index  int
+	name   string
+	checks []check
+}
+
+func (r *Registry) plan(t reflect.Type) (*structPlan, error) {
+	r.mu.RLock()
+	p, ok := r.plans[t]
+	r.mu.RUnlock()
+	if ok {
+		return p, nil
+	}
+	p, err := r.buildPlan(t)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	r.plans[t] = p
+	r.mu.Unlock()
+	return p, nil
+}
+
+func (r *Registry) buildPlan(t reflect.Type) (*structPlan, error) {
+	p := &structPlan{}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		f := fieldPlan{index: i, name: fieldName(sf)}
+		if tag, ok := sf.Tag.Lookup(TagName); ok && tag != "" && tag != "-" {
+			for _, item := range strings.Split(tag, ",") {
+				c, err := r.parseItem(sf.Type, strings.TrimSpace(item))
+				if err != nil {
+					return nil, fmt.Errorf("structtag: %s.%s: %w", t, sf.Name, err)
+				}
+				f.checks = append(f.checks, c)
+			}
+		}
+		p.fields = append(p.fields, f)
+	}
+	return p, nil
+}
+
+func fieldName(sf reflect.StructField) string {
+	if tag, ok := sf.Tag.Lookup("json"); ok {
+		if name := strings.Split(tag, ",")[0]; name != "" && name != "-" {
+			return name
+		}
+	}
+	return sf.Name
+}
+
+func (r *Registry) parseItem(t reflect.Type, item string) (check, error) {
+	key, arg, hasArg := strings.Cut(item, "=")
+	if !hasArg {
+		if key == "required" {
+			return requiredCheck(t), nil
+		}
+		r.mu.RLock()
+		nc, ok := r.named[key]
+		r.mu.RUnlock()
+		if !ok {
+			return nil, fmt.Errorf("unknown constraint %q", key)
+		}
+		if !compatibleType(t, nc.valueType) {
+			return nil, fmt.Errorf("constraint %q is for %s, not %s", key, nc.valueType, t)
+		}
+		return nc.check, nil
+	}
+
+	switch key {
+	case "len", "minlen", "maxlen":
+		if t.Kind() != reflect.String {
+			return nil, fmt.Errorf("%s is only for strings", key)
+		}
+		n, err := strconv.Atoi(arg)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("%s: invalid length %q", key, arg)
+		}
+		var c constraints.Constraint[string]
+		switch key {
+		case "len":
+			c = stdtypes.StringRuneLength(n)
+		case "minlen":
+			c = stdtypes.StringRuneMinLength(n)
+		default:
+			c = stdtypes.StringRuneMaxLength(n)
+		}
+		return typedCheck(c), nil
+	case "prefix", "suffix":
+		if t.Kind() != reflect.String {
+			return nil, fmt.Errorf("%s is only for strings", key)
+		}
+		if key == "prefix" {
+			return typedCheck(stdtypes.StringPrefix(arg)), nil
+		}
+		return typedCheck(stdtypes.StringSuffix(arg)), nil
+	case "min", "max", "range":
+		return boundsCheck(t, key, arg)
+	case "oneof", "noneof":
+		return choiceCheck(t, key, strings.Split(arg, "|"))
+	}
+	return nil, fmt.Errorf("unknown constraint %q", key)
+}
+
+// compatibleType returns true if the values of type t could be validated
+// by the constraints for type target, i.e., t is target or a type
+// defined on the same underlying type, e.g., a named string type for
+// string. Conversions between kinds, e.g., from int to string, which
+// is a rune conversion, are not allowed.
+func compatibleType(t, target reflect.Type) bool {
+	return t.Kind() == target.Kind() && t.ConvertibleTo(target)
+}
+
+func requiredCheck(t reflect.Type) check {
+	if t.Kind() == reflect.String {
+		return typedCheck(stdtypes.NonEmptyString)
+	}
+	c := constraints.Func("required",
+		func(v any) bool {
+			// A nil interface field has no value to reflect on.
+			rv := reflect.ValueOf(v)
+			return rv.IsValid() && !rv.IsZero()
+		},
+		constraints.WithMessage("required", nil))
+	return func(v reflect.Value) error {
+		// The field is checked, instead of its content, so that an
+		// interface field holding a zero value, e.g., 0, is present.
+		if !v.IsZero() {
+			return nil
+		}
+		return constraints.ValidOrError(v.Interface(), c)
+	}
+}
+
+func boundsCheck(t reflect.Type, key, arg string) (check, error) {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return numericBoundsCheck(t, key, arg, func(s string) (int64, error) {
+			return strconv.ParseInt(s, 10, 64)
+		})
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return numericBoundsCheck(t, key, arg, func(s string) (uint64, error) {
+			return strconv.ParseUint(s, 10, 64)
+		})
+	case reflect.Float32, reflect.Float64:
+		return numericBoundsCheck(t, key, arg, func(s string) (float64, error) {
+			return strconv.ParseFloat(s, 64)
+		})
+	}
+	return nil, fmt.Errorf("%s is only for numbers", key)
+}
+
+func numericBoundsCheck[ValueT int64 | uint64 | float64](
+	t reflect.Type,
+	key, arg string,
+	parse func(string) (ValueT, error),
+) (check, error) {
+	if key == "range" {
+		lo, hi, ok := strings.Cut(arg, "..")
+		if !ok {
+			return nil, fmt.Errorf("range: expecting min..max, got %q", arg)
+		}
+		min, err := parse(lo)
+		if err != nil {
+			return nil, fmt.Errorf("range: %w", err)
+		}
+		max, err := parse(hi)
+		if err != nil {
+			return nil, fmt.Errorf("range: %w", err)
+		}
+		return typedCheck(constraints.Range(min, max)), nil
+	}
+	n, err := parse(arg)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", key, err)
+	}
+	if key == "min" {
+		return typedCheck[ValueT](constraints.Min(n)), nil
+	}
+	return typedCheck[ValueT](constraints.Max(n)), nil
+}
+
+func choiceCheck(t reflect.Type, key string, options []string) (check, error) {
+	switch t.Kind() {
+	case reflect.String:
+		return typedCheck(choice(key, options)), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return numericChoiceCheck(key, options, func(s string) (int64, error) {
+			return strconv.ParseInt(s, 10, 64)
+		})
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return numericChoiceCheck(key, options, func(s string) (uint64, error) {
+			return strconv.ParseUint(s, 10, 64)
+		})
+	case reflect.Float32, reflect.Float64:
+		// The options are rounded to the precision of the field so that
+		// a float32 field could equal them.
+		return numericChoiceCheck(key, options, func(s string) (float64, error) {
+			return strconv.ParseFloat(s, t.Bits())
+		})
+	}
+	return nil, fmt.Errorf("%s is only for strings and numbers", key)
+}
+
+func numericChoiceCheck[ValueT int64 | uint64 | float64](
+	key string,
+	options []string,
+	parse func(string) (ValueT, error),
+) (check, error) {
+	parsed := make([]ValueT, 0, len(options))
+	for _, o := range options {
+		n, err := parse(o)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		parsed = append(parsed, n)
+	}
+	return typedCheck(choice(key, parsed)), nil
+}
+
+func choice[ValueT comparable](key string, options []ValueT) constraints.Constraint[ValueT] {
+	if key == "noneof" {
+		return constraints.NoneOf(options...)
+	}
+	return constraints.OneOf(options...)
+}
+
+// typedCheck creates a check which converts field values into ValueT,
+// e.g., a named string type into string or an int8 into int64, before
+// validating them against c. The fields must be convertible to ValueT.
+func typedCheck[ValueT any](c constraints.Constraint[ValueT]) check {
+	target := reflect.TypeOf((*ValueT)(nil)).Elem()
+	return func(v reflect.Value) error {
+		if v.Type() != target {
+			v = v.Convert(target)
+		}
+		return constraints.ValidOrError(v.Interface().(ValueT), c)
+	}
+}
@@ -0,0 +1,177 @@
+package structtag
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rez-go/constraints"
+	ctesting "github.com/rez-go/constraints/internal/testing"
+	"github.com/rez-go/constraints/stdtypes"
+)
+
+var assertEq = ctesting.AssertEq
+
+type testAddress struct {
+	Zip string `json:"zip" constraint:"len=5"`
+}
+
+type testRole string
+
+type testRequest struct {
+	Username  string        `json:"username" constraint:"username"`
+	Password  string        `json:"password" constraint:"minlen=8,maxlen=64"`
+	Role      testRole      `json:"role" constraint:"oneof=admin|member"`
+	Age       int           `json:"age" constraint:"range=13..130"`
+	Score     float64       `constraint:"min=0.5"`
+	Tags      []string      `json:"tags" constraint:"required"`
+	Addresses []testAddress `json:"addresses"`
+	internal  string
+}
+
+func newTestRegistry() *Registry {
+	r := NewRegistry()
+	Register[string](r, "username", constraints.Set(
+		stdtypes.StringMinLength(3),
+		stdtypes.NonBlankString,
+	))
+	return r
+}
+
+func TestValidate(t *testing.T) {
+	r := newTestRegistry()
+	valid := testRequest{
+		Username:  "alice",
+		Password:  "correct horse",
+		Role:      "admin",
+		Age:       30,
+		Score:     1,
+		Tags:      []string{"a"},
+		Addresses: []testAddress{{Zip: "12345"}},
+	}
+	assertEq(t, nil, r.Validate(valid))
+	assertEq(t, nil, r.Validate(&valid))
+
+	invalid := testRequest{
+		Username:  "al",
+		Password:  "short",
+		Role:      "root",
+		Age:       10,
+		Score:     0,
+		Addresses: []testAddress{{Zip: "12345"}, {Zip: "1"}},
+	}
+	err := r.Validate(&invalid)
+	var errs constraints.ErrorList
+	assertEq(t, true, errors.As(err, &errs))
+	paths := make([]string, 0, len(errs))
+	for _, fe := range errs {
+		paths = append(paths, fe.Path.String())
+	}
+	assertEq(t, []string{
+		"username", "password", "role", "age", "Score", "tags", "addresses[1].zip",
+	}, paths)
+
+	b, _ := json.Marshal(errs[1])
+	assertEq(t, `{"field":"password","pointer":"/password","code":"rune_length.min",`+
+		`"params":{"min":8,"unit":"runes"},"description":"min rune length 8"}`, string(b))
+	assertEq(t, "required", errs[5].Err.(constraints.Error[any]).Code())
+}
+
+func TestValidateLengthInRunes(t *testing.T) {
+	type request struct {
+		Name string `constraint:"minlen=3,maxlen=3"`
+	}
+	r := NewRegistry()
+	// "日本語" is 9 bytes long.
+	assertEq(t, nil, r.Validate(request{Name: "日本語"}))
+	assertEq(t, true, r.Validate(request{Name: "日本"}) != nil)
+}
+
+func TestValidateInvalidTags(t *testing.T) {
+	r := NewRegistry()
+	err := r.Validate(struct {
+		Name string `constraint:"unknown"`
+	}{})
+	assertEq(t, true, strings.Contains(err.Error(), `unknown constraint "unknown"`))
+
+	err = r.Validate(struct {
+		Age int `constraint:"minlen=1"`
+	}{})
+	assertEq(t, true, strings.Contains(err.Error(), "minlen is only for strings"))
+
+	Register[int](r, "adult", constraints.Min(18))
+	err = r.Validate(struct {
+		Name string `constraint:"adult"`
+	}{})
+	assertEq(t, true, strings.Contains(err.Error(), `constraint "adult" is for int, not string`))
+
+	assertEq(t, true, r.Validate(42) != nil)
+
+	// Conversions between kinds are not allowed, e.g., int to string
+	// which would be a rune conversion.
+	Register[string](r, "name", stdtypes.NonEmptyString)
+	err = r.Validate(struct {
+		Name int `constraint:"name"`
+	}{})
+	assertEq(t, true, strings.Contains(err.Error(), `constraint "name" is for string, not int`))
+
+	err = r.Validate(struct {
+		Age float64 `constraint:"adult"`
+	}{})
+	assertEq(t, true, strings.Contains(err.Error(), `constraint "adult" is for int, not float64`))
+}
+
+type testUsername string
+
+func TestValidateNamedType(t *testing.T) {
+	r := newTestRegistry()
+	type request struct {
+		Username testUsername `json:"username" constraint:"username"`
+	}
+	assertEq(t, nil, r.Validate(request{Username: "alice"}))
+
+	var errs constraints.ErrorList
+	assertEq(t, true, errors.As(r.Validate(request{Username: "al"}), &errs))
+	assertEq(t, 1, len(errs))
+	assertEq(t, "username", errs[0].Path.String())
+}
+
+func TestValidateRequiredNil(t *testing.T) {
+	r := NewRegistry()
+	var errs constraints.ErrorList
+	assertEq(t, true, errors.As(r.Validate(struct {
+		I any            `json:"i" constraint:"required"`
+		P *int           `json:"p" constraint:"required"`
+		M map[string]int `json:"m" constraint:"required"`
+	}{}), &errs))
+	paths := make([]string, 0, len(errs))
+	for _, fe := range errs {
+		paths = append(paths, fe.Path.String())
+	}
+	assertEq(t, []string{"i", "p", "m"}, paths)
+
+	n := 0
+	assertEq(t, nil, r.Validate(struct {
+		I any            `constraint:"required"`
+		P *int           `constraint:"required"`
+		M map[string]int `constraint:"required"`
+	}{I: 0, P: &n, M: map[string]int{}}))
+}
+
+func TestValidateNumericChoices(t *testing.T) {
+	r := NewRegistry()
+	type request struct {
+		Port  uint16  `constraint:"oneof=80|443"`
+		Ratio float32 `constraint:"noneof=0.1|0.5"`
+	}
+	assertEq(t, nil, r.Validate(request{Port: 443, Ratio: 0.2}))
+	var errs constraints.ErrorList
+	assertEq(t, true, errors.As(r.Validate(request{Port: 8080, Ratio: 0.1}), &errs))
+	assertEq(t, 2, len(errs))
+
+	err := r.Validate(struct {
+		On bool `constraint:"oneof=true"`
+	}{})
+	assertEq(t, true, strings.Contains(err.Error(), "oneof is only for strings and numbers"))
+}
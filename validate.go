@@ -0,0 +1,282 @@
+package constraints
+
+import "sort"
+
+// A ValidateOption configures ValidateWith.
+//
+// API status: experimental
+type ValidateOption func(*validateOptions)
+
+type validateOptions struct {
+	failFast      bool
+	maxViolations int
+	byCost        bool
+}
+
+// FailFast makes ValidateWith stop at the first violated constraint.
+func FailFast() ValidateOption {
+	return func(o *validateOptions) {
+		o.failFast = true
+	}
+}
+
+// MaxViolations makes ValidateWith stop once n violated constraints
+// have been found. Values less than 1 mean no limit.
+func MaxViolations(n int) ValidateOption {
+	return func(o *validateOptions) {
+		o.maxViolations = n
+	}
+}
+
+// OrderByCost makes ValidateWith evaluate the members of a set in
+// ascending order of their costs, e.g., length checks before regular
+// expressions. Members with the same cost keep their order. See CostOf.
+func OrderByCost() ValidateOption {
+	return func(o *validateOptions) {
+		o.byCost = true
+	}
+}
+
+// ValidateWith is like ValidOrError but configurable with options,
+// e.g.,
+//
+//	err := ValidateWith(username, usernameConstraints,
+//		OrderByCost(), MaxViolations(3))
+//
+// The options apply to the constraints which ValidOrError validates
+// with their ValidateAll method, e.g., the members of a Set, the fields
+// of a Struct and the active branch of a When. Like ValidOrError,
+// the error contains a Set of the violated members, in the order they
+// were evaluated.
+//
+// API status: experimental
+func ValidateWith[ValueT any](
+	v ValueT, c Constraint[ValueT], opts ...ValidateOption,
+) error {
+	var o validateOptions
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+	if c == nil {
+		return ViolationError(c)
+	}
+	if _, ok := c.(interface {
+		ValidateAll(ValueT) []Constraint[ValueT]
+	}); !ok {
+		if c.IsValid(v) {
+			return nil
+		}
+		return ViolationError(c)
+	}
+
+	violated := validateAllWith(v, c, o)
+	if len(violated) > 0 {
+		return ViolationError[ValueT](Set(violated...))
+	}
+	return nil
+}
+
+// validateAllWith is like the ValidateAll method of c, but the members
+// are evaluated according to the options.
+func validateAllWith[ValueT any](
+	v ValueT, c Constraint[ValueT], o validateOptions,
+) []Constraint[ValueT] {
+	limit := o.maxViolations
+	if o.failFast {
+		limit = 1
+	}
+
+	if cc, ok := c.(*conditionalConstraint[ValueT]); ok {
+		branch, condMet := cc.branch(v)
+		if branch == nil {
+			return nil
+		}
+		var violated []Constraint[ValueT]
+		for _, vc := range validateAllWith(v, branch, o) {
+			violated = append(violated, cc.wrap(vc, condMet))
+		}
+		return violated
+	}
+
+	members, ok := members(c)
+	if !ok {
+		if cs, ok := c.(interface {
+			ValidateAll(ValueT) []Constraint[ValueT]
+		}); ok && cs != nil {
+			violated := cs.ValidateAll(v)
+			if limit > 0 && len(violated) > limit {
+				violated = violated[:limit]
+			}
+			return violated
+		}
+		if !c.IsValid(v) {
+			return []Constraint[ValueT]{c}
+		}
+		return nil
+	}
+
+	if o.byCost {
+		costs := make([]Cost, len(members))
+		for i, m := range members {
+			costs[i] = CostOf(m)
+		}
+		sort.Stable(byCost[ValueT]{members, costs})
+	}
+	var violated []Constraint[ValueT]
+	for _, m := range members {
+		if !m.IsValid(v) {
+			violated = append(violated, m)
+			if limit > 0 && len(violated) >= limit {
+				break
+			}
+		}
+	}
+	return violated
+}
+
+// members returns a copy of the members of c if c is a Set or
+// a Struct, i.e., a constraint whose ValidateAll method returns its
+// violated children.
+func members[ValueT any](c Constraint[ValueT]) ([]Constraint[ValueT], bool) {
+	if kind := KindOf(c); kind != KindSet && kind != KindStruct {
+		return nil, false
+	}
+	cc, ok := c.(CompositeConstraint[ValueT])
+	if !ok {
+		return nil, false
+	}
+	children := cc.Children()
+	members := make([]Constraint[ValueT], len(children))
+	copy(members, children)
+	return members, true
+}
+
+type byCost[ValueT any] struct {
+	list  []Constraint[ValueT]
+	costs []Cost
+}
+
+func (s byCost[ValueT]) Len() int           { return len(s.list) }
+func (s byCost[ValueT]) Less(i, j int) bool { return s.costs[i] < s.costs[j] }
+func (s byCost[ValueT]) Swap(i, j int) {
+	s.list[i], s.list[j] = s.list[j], s.list[i]
+	s.costs[i], s.costs[j] = s.costs[j], s.costs[i]
+}
+
+//----
+
+// DependsOn creates a Constraint which evaluates constraint c only for
+// values which satisfy all the prerequisites. Values which don't are
+// considered valid by the constraint, on the assumption that
+// the prerequisites are validated on their own, e.g., earlier in the
+// same Set:
+//
+//	Set(stdtypes.NonEmptyString,
+//		DependsOn(startsWithLetter, stdtypes.NonEmptyString))
+//
+// reports only stdtypes.NonEmptyString for an empty string.
+//
+// The constraint takes the description, the message and the code of c,
+// but has its own kind, KindDependsOn, as it doesn't implement
+// the accessor interfaces of c, e.g., OperandConstraint. Exporters and
+// Walk find c among its children.
+//
+// API status: experimental
+func DependsOn[ValueT any](
+	c Constraint[ValueT], prerequisites ...Constraint[ValueT],
+) DependentConstraint[ValueT] {
+	copies := make([]Constraint[ValueT], len(prerequisites))
+	copy(copies, prerequisites)
+	return &dependentConstraint[ValueT]{c: c, prerequisites: copies}
+}
+
+// A DependentConstraint is a constraint which is evaluated only if
+// its prerequisites are satisfied.
+//
+// API status: experimental
+type DependentConstraint[ValueT any] interface {
+	Constraint[ValueT]
+
+	// Dependent returns the constraint which depends on
+	// the prerequisites.
+	Dependent() Constraint[ValueT]
+
+	// Prerequisites returns a copy of the prerequisites.
+	Prerequisites() []Constraint[ValueT]
+}
+
+type dependentConstraint[ValueT any] struct {
+	c             Constraint[ValueT]
+	prerequisites []Constraint[ValueT]
+}
+
+var (
+	_ DependentConstraint[string] = &dependentConstraint[string]{}
+	_ CompositeConstraint[string] = &dependentConstraint[string]{}
+	_ KindedConstraint            = &dependentConstraint[string]{}
+	_ MessageConstraint           = &dependentConstraint[string]{}
+	_ CodedConstraint             = &dependentConstraint[string]{}
+	_ CostedConstraint            = &dependentConstraint[string]{}
+)
+
+// ConstraintDescription conforms Constraint interface.
+func (c *dependentConstraint[ValueT]) ConstraintDescription() string {
+	return c.c.ConstraintDescription()
+}
+
+// ConstraintKind conforms KindedConstraint interface.
+func (c *dependentConstraint[ValueT]) ConstraintKind() Kind {
+	return KindDependsOn
+}
+
+// ConstraintMessage conforms MessageConstraint interface.
+func (c *dependentConstraint[ValueT]) ConstraintMessage() Message {
+	return MessageOf(c.c)
+}
+
+// ConstraintCode conforms CodedConstraint interface.
+func (c *dependentConstraint[ValueT]) ConstraintCode() string {
+	return CodeOf(c.c)
+}
+
+// ConstraintCost conforms CostedConstraint interface. It includes
+// the costs of the prerequisites.
+func (c *dependentConstraint[ValueT]) ConstraintCost() Cost {
+	cost := CostOf(c.c)
+	for _, p := range c.prerequisites {
+		cost += CostOf(p)
+	}
+	return cost
+}
+
+// Dependent conforms DependentConstraint interface.
+func (c *dependentConstraint[ValueT]) Dependent() Constraint[ValueT] {
+	return c.c
+}
+
+// Prerequisites conforms DependentConstraint interface.
+func (c *dependentConstraint[ValueT]) Prerequisites() []Constraint[ValueT] {
+	copies := make([]Constraint[ValueT], len(c.prerequisites))
+	copy(copies, c.prerequisites)
+	return copies
+}
+
+// Children conforms CompositeConstraint interface. It returns
+// the prerequisites followed by the dependent.
+func (c *dependentConstraint[ValueT]) Children() []Constraint[ValueT] {
+	children := make([]Constraint[ValueT], 0, len(c.prerequisites)+1)
+	children = append(children, c.prerequisites...)
+	return append(children, c.c)
+}
+
+// IsValid conforms Constraint interface.
+func (c *dependentConstraint[ValueT]) IsValid(v ValueT) bool {
+	for _, p := range c.prerequisites {
+		if !p.IsValid(v) {
+			return true
+		}
+	}
+	return c.c.IsValid(v)
+}
@@ -0,0 +1,89 @@
+package constraints
+
+import "testing"
+
+func violatedDescription[ValueT any](err error) string {
+	c := ViolatedConstraintFromError[ValueT](err)
+	if c == nil {
+		return ""
+	}
+	return c.ConstraintDescription()
+}
+
+func TestValidateWith(t *testing.T) {
+	c := Set[int](Min(0), NoneOf(-1, 11), Max(10), GreaterThan(-1))
+
+	assertEq(t, nil, ValidateWith[int](5, c))
+	assertEq(t, "min 0, none of [-1, 11], greater than -1",
+		violatedDescription[int](ValidateWith[int](-1, c)))
+	assertEq(t, "min 0", violatedDescription[int](ValidateWith[int](-1, c, FailFast())))
+	assertEq(t, "min 0, none of [-1, 11]",
+		violatedDescription[int](ValidateWith[int](-1, c, MaxViolations(2))))
+	assertEq(t, "max 10", violatedDescription[int](ValidateWith[int](11, Max(10))))
+}
+
+func TestValidateWithComposites(t *testing.T) {
+	invalid := testUser{Username: "root", Age: 10}
+	assertEq(t, "username: none of [, root], age: from 13 to 130, address: zip: non-empty",
+		violatedDescription[testUser](ValidateWith[testUser](invalid, testUserConstraints)))
+	assertEq(t, violatedDescription[testUser](ValidOrError[testUser](invalid, testUserConstraints)),
+		violatedDescription[testUser](ValidateWith[testUser](invalid, testUserConstraints)))
+	assertEq(t, "username: none of [, root]",
+		violatedDescription[testUser](ValidateWith[testUser](invalid, testUserConstraints, FailFast())))
+	assertEq(t, KindSet, KindOf(ViolatedConstraintFromError[testUser](
+		ValidateWith[testUser](invalid, testUserConstraints, FailFast()))))
+
+	c := When[int](Min(0), Set[int](Max(10), NoneOf(11, 12), Negate[int](Match(12), "not 12")), nil)
+	assertEq(t, "max 10 when min 0, none of [11, 12] when min 0, not 12 when min 0",
+		violatedDescription[int](ValidateWith[int](12, c)))
+	assertEq(t, "max 10 when min 0, none of [11, 12] when min 0",
+		violatedDescription[int](ValidateWith[int](12, c, MaxViolations(2))))
+	assertEq(t, nil, ValidateWith[int](-1, c, FailFast()))
+}
+
+func TestValidateWithOrderByCost(t *testing.T) {
+	expensive := Func("expensive", func(v int) bool { return v%2 == 0 },
+		WithCost(CostExpensive))
+	c := Set[int](expensive, Func("default", func(v int) bool { return v > 100 }), Max(10))
+
+	assertEq(t, CostExpensive, CostOf[int](expensive))
+	assertEq(t, CostDefault+CostExpensive+CostCheap, CostOf[int](c))
+
+	assertEq(t, "max 10, default, expensive",
+		violatedDescription[int](ValidateWith[int](11, c, OrderByCost())))
+	assertEq(t, "max 10",
+		violatedDescription[int](ValidateWith[int](11, c, OrderByCost(), FailFast())))
+	// The set itself is not reordered.
+	assertEq(t, "expensive, default, max 10", c.ConstraintDescription())
+}
+
+func TestDependsOn(t *testing.T) {
+	nonZero := Negate[int](Match(0), "non-zero")
+	divides := Func("divides 12", func(v int) bool { return 12%v == 0 })
+	c := Set[int](nonZero, DependsOn(divides, nonZero))
+
+	assertEq(t, "non-zero", violatedDescription[int](ValidOrError[int](0, c)))
+	assertEq(t, "divides 12", violatedDescription[int](ValidOrError[int](5, c)))
+	assertEq(t, nil, ValidOrError[int](4, c))
+
+	dc := c.ConstraintList()[1].(DependentConstraint[int])
+	assertEq(t, "divides 12", dc.Dependent().ConstraintDescription())
+	assertEq(t, 1, len(dc.Prerequisites()))
+	assertEq(t, CostDefault+CostCheap, CostOf[int](dc))
+
+	// It doesn't pretend to be its dependent, whose accessor interfaces
+	// it doesn't implement, but exposes it as a child.
+	assertEq(t, KindDependsOn, KindOf(dc))
+	assertEq(t, "", CodeOf(dc))
+	var kinds []Kind
+	Inspect[int](dc, func(c Constraint[int], depth int, path []int) bool {
+		kinds = append(kinds, KindOf(c))
+		return true
+	})
+	assertEq(t, []Kind{KindDependsOn, KindNegate, KindMatch, KindFunc}, kinds)
+
+	pattern := DependsOn[string](Match("x"), Negate[string](Match(""), "non-empty"))
+	_, isOperand := Constraint[string](pattern).(OperandConstraint[string])
+	assertEq(t, false, isOperand)
+	assertEq(t, "match", CodeOf(pattern))
+}
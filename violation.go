@@ -0,0 +1,77 @@
+package constraints
+
+// A Violation is the structured representation of a violated
+// constraint. It's designed to be encoded, e.g., as JSON, and put into
+// responses.
+//
+// API status: experimental
+type Violation struct {
+	// Code is the machine-readable code of the constraint. See CodeOf.
+	Code string `json:"code,omitempty"`
+	// Params holds the parameters of the constraint. See ParamsOf.
+	Params map[string]any `json:"params,omitempty"`
+	// Description is the English description of the constraint.
+	Description string `json:"description"`
+	// Violations contains the violations of the members of a Set.
+	Violations []Violation `json:"violations,omitempty"`
+}
+
+// ViolationOf creates a Violation from violated constraint c. If c is
+// a Set, the Violation will contain a Violation for each of its members.
+//
+// The parameters which are messages of other constraints, e.g., the
+// alternatives of Any, are converted into maps which contain "code",
+// "params" and "description".
+func ViolationOf[ValueT any](c Constraint[ValueT]) Violation {
+	if c == nil {
+		return Violation{}
+	}
+	v := Violation{
+		Code:        CodeOf(c),
+		Description: c.ConstraintDescription(),
+	}
+	if KindOf(c) == KindSet {
+		if cc, ok := c.(CompositeConstraint[ValueT]); ok {
+			for _, child := range cc.Children() {
+				v.Violations = append(v.Violations, ViolationOf(child))
+			}
+			return v
+		}
+	}
+	v.Params = violationParams(ParamsOf(c))
+	return v
+}
+
+func violationParams(args map[string]any) map[string]any {
+	if len(args) == 0 {
+		return nil
+	}
+	params := make(map[string]any, len(args))
+	for k, arg := range args {
+		params[k] = violationParam(arg)
+	}
+	return params
+}
+
+func violationParam(arg any) any {
+	switch ta := arg.(type) {
+	case Message:
+		m := map[string]any{"description": ta.Text}
+		if ta.code != "" {
+			m["code"] = ta.code
+		} else if ta.ID != "" {
+			m["code"] = ta.ID
+		}
+		if params := violationParams(ta.Args); params != nil {
+			m["params"] = params
+		}
+		return m
+	case []Message:
+		list := make([]any, 0, len(ta))
+		for _, msg := range ta {
+			list = append(list, violationParam(msg))
+		}
+		return list
+	}
+	return arg
+}
@@ -0,0 +1,62 @@
+package constraints
+
+// CompositeConstraint is implemented by constraints which are constructed
+// from other constraints, e.g., Set, Any and Negate. User-defined
+// composite constraints should implement this interface so that Walk
+// could descend into them.
+type CompositeConstraint[ValueT any] interface {
+	Constraint[ValueT]
+
+	// Children returns the constraints this constraint is constructed of.
+	Children() []Constraint[ValueT]
+}
+
+// A Visitor's Visit method is invoked for each constraint encountered
+// by Walk. If the result visitor w is not nil, Walk visits each of
+// the children of c with the visitor w.
+//
+// The depth of the root constraint is 0. The path contains the indices
+// of the constraints, as returned by Children, from the root to c.
+// The path is reused by Walk thus it must not be retained.
+type Visitor[ValueT any] interface {
+	Visit(c Constraint[ValueT], depth int, path []int) (w Visitor[ValueT])
+}
+
+// Walk traverses a constraint tree in depth-first order. It starts by
+// calling v.Visit(c, 0, []int{}). If the visitor returned by Visit is
+// not nil, Walk is invoked recursively for each of the children of c.
+//
+// API status: experimental
+func Walk[ValueT any](v Visitor[ValueT], c Constraint[ValueT]) {
+	walk(v, c, make([]int, 0, 8))
+}
+
+func walk[ValueT any](v Visitor[ValueT], c Constraint[ValueT], path []int) {
+	if c == nil {
+		return
+	}
+	if v = v.Visit(c, len(path), path); v == nil {
+		return
+	}
+	if cc, ok := c.(CompositeConstraint[ValueT]); ok {
+		for i, child := range cc.Children() {
+			walk(v, child, append(path, i))
+		}
+	}
+}
+
+// Inspect traverses a constraint tree in depth-first order. It calls
+// f for each constraint in the tree. If f returns true, Inspect will
+// descend into the children of the constraint.
+func Inspect[ValueT any](c Constraint[ValueT], f func(c Constraint[ValueT], depth int, path []int) bool) {
+	Walk[ValueT](inspector[ValueT](f), c)
+}
+
+type inspector[ValueT any] func(c Constraint[ValueT], depth int, path []int) bool
+
+func (f inspector[ValueT]) Visit(c Constraint[ValueT], depth int, path []int) Visitor[ValueT] {
+	if f(c, depth, path) {
+		return f
+	}
+	return nil
+}
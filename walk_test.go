@@ -0,0 +1,54 @@
+package constraints
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSetConstraintList(t *testing.T) {
+	min, max := Min(1), Max(10)
+	cs := Set[int](min, max)
+	assertEq(t, []Constraint[int]{min, max}, cs.ConstraintList())
+}
+
+func TestWalk(t *testing.T) {
+	c := Set[int](
+		Min(1),
+		Any[int](Match(5), Negate[int](Range(10, 20), "")),
+		Max(100),
+	)
+	var lines []string
+	Inspect[int](c, func(c Constraint[int], depth int, path []int) bool {
+		lines = append(lines, fmt.Sprintf("%s%v %s",
+			strings.Repeat("  ", depth), path, KindOf(c)))
+		return true
+	})
+	assertEq(t, []string{
+		"[] set",
+		"  [0] min",
+		"  [1] any",
+		"    [1 0] match",
+		"    [1 1] not",
+		"      [1 1 0] range",
+		"  [2] max",
+	}, lines)
+}
+
+func TestWalkSkipChildren(t *testing.T) {
+	c := Set[int](Min(1), Any[int](Match(5), Match(6)))
+	var kinds []Kind
+	Inspect[int](c, func(c Constraint[int], depth int, path []int) bool {
+		kinds = append(kinds, KindOf(c))
+		return KindOf(c) != KindAny
+	})
+	assertEq(t, []Kind{KindSet, KindMin, KindAny}, kinds)
+}
+
+func TestNegate(t *testing.T) {
+	c := Negate[int](Min(5), "")
+	assertEq(t, "not min 5", c.ConstraintDescription())
+	assertEq(t, true, c.IsValid(4))
+	assertEq(t, false, c.IsValid(5))
+	assertEq(t, KindNegate, KindOf(c))
+}